@@ -0,0 +1,124 @@
+package goconstants
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors returned by Validate for aliases.
+var (
+	ErrAliasConflict     = errors.New("an alias is already used")
+	ErrAliasUnknownValue = errors.New("an alias is defined for an unknown value")
+)
+
+// getAliases returns the aliases of the given representation, following
+// the same fallback rules as the strings. The database representation has
+// no aliases.
+func (meta Metadata[T]) getAliases(r Representation) map[T][]string {
+	switch r {
+	case StringRepresentation:
+		if meta.Strings != nil {
+			return meta.Aliases
+		}
+		return meta.JSONAliases
+	case JSONRepresentation:
+		if meta.JSONStrings != nil {
+			return meta.JSONAliases
+		}
+		return meta.Aliases
+	default:
+		return nil
+	}
+}
+
+// fromAlias converts an alias to its associated constant value, and a
+// boolean indicating if the alias is known. OnAlias is called if the alias
+// is known.
+func (meta Metadata[T]) fromAlias(representation string, r Representation) (T, bool) {
+	key := meta.Parsing.key(representation)
+
+	value, ok := meta.lookupAlias(key, r)
+	if ok && meta.OnAlias != nil {
+		meta.OnAlias(value, representation)
+	}
+
+	return value, ok
+}
+
+// lookupAlias finds the value of an alias key, using the index if available.
+func (meta Metadata[T]) lookupAlias(key string, r Representation) (T, bool) {
+	if meta.index != nil {
+		value, ok := meta.index.aliases[r][key]
+		return value, ok
+	}
+
+	for value, aliases := range meta.getAliases(r) {
+		for _, alias := range aliases {
+			if key == meta.Parsing.key(alias) {
+				return value, true
+			}
+		}
+	}
+
+	var zero T
+	return zero, false
+}
+
+// reverseAliases builds a map from aliases, transformed as specified by the
+// parse options, to constant values.
+func reverseAliases[T comparable](aliases map[T][]string, opts ParseOptions) map[string]T {
+	reverse := make(map[string]T)
+	for value, valueAliases := range aliases {
+		for _, alias := range valueAliases {
+			reverse[opts.key(alias)] = value
+		}
+	}
+
+	return reverse
+}
+
+// aliasesProblems returns the problems of the aliases of a representation:
+// aliases of unknown values, and aliases clashing with a string or with
+// another alias.
+func (meta Metadata[T]) aliasesProblems(field string, aliases map[T][]string, r Representation) []error {
+	var problems []error
+
+	strings := meta.getRepresentation(r)
+	used := make(map[string]string, len(strings))
+	for value, representation := range strings {
+		used[meta.Parsing.key(representation)] = fmt.Sprintf("the string of %#v", value)
+	}
+
+	for _, value := range sortedKeys(aliases) {
+		if _, ok := strings[value]; !ok {
+			problems = append(problems, fmt.Errorf("%w in %s: %#v", ErrAliasUnknownValue, field, value))
+			continue
+		}
+
+		for _, alias := range aliases[value] {
+			key := meta.Parsing.key(alias)
+			if user, ok := used[key]; ok {
+				problems = append(problems, fmt.Errorf("%w in %s: %q of %#v clashes with %s",
+					ErrAliasConflict, field, alias, value, user))
+				continue
+			}
+			used[key] = fmt.Sprintf("an alias of %#v", value)
+		}
+	}
+
+	return problems
+}
+
+// copyAliases returns a deep copy of aliases, or nil if aliases is nil.
+func copyAliases[T comparable](aliases map[T][]string) map[T][]string {
+	if aliases == nil {
+		return nil
+	}
+
+	c := make(map[T][]string, len(aliases))
+	for value, valueAliases := range aliases {
+		c[value] = append([]string{}, valueAliases...)
+	}
+
+	return c
+}
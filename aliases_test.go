@@ -0,0 +1,136 @@
+package goconstants_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/samonzeweb/goconstants"
+)
+
+func TestAliases(t *testing.T) {
+	type used struct {
+		value simpson
+		alias string
+	}
+	var usages []used
+
+	meta := cstMeta
+	meta.Aliases = map[simpson][]string{
+		homer: {"Homer J. Simpson", "Max Power"},
+	}
+	meta.JSONAliases = map[simpson][]string{
+		bart: {"el_barto"},
+	}
+	meta.Parsing = goconstants.ParseOptions{CaseFolding: goconstants.ASCIIFolding}
+	meta.OnAlias = func(v simpson, alias string) {
+		usages = append(usages, used{v, alias})
+	}
+
+	if err := meta.Validate(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	for _, compiled := range []bool{false, true} {
+		if compiled {
+			meta = meta.Compile()
+		}
+		usages = nil
+
+		value, ok := meta.FromStringHelper("max power")
+		if !ok || value != homer {
+			t.Errorf("expected %v, got %v (compiled: %t)", homer, value, compiled)
+		}
+
+		if _, ok := meta.FromStringHelper("el_barto"); ok {
+			t.Errorf("JSON aliases should not be accepted as strings (compiled: %t)", compiled)
+		}
+
+		err := meta.UnmarshalJSONHelper([]byte(`"El_Barto"`), &value)
+		if err != nil || value != bart {
+			t.Errorf("expected %v, got %v (%v, compiled: %t)", bart, value, err, compiled)
+		}
+
+		err = meta.UnmarshalTextHelper([]byte("el_barto"), &value)
+		if err != nil || value != bart {
+			t.Errorf("expected %v, got %v (%v, compiled: %t)", bart, value, err, compiled)
+		}
+
+		// Canonical strings don't trigger the hook.
+		if _, ok := meta.FromStringHelper("Homer Simpson"); !ok {
+			t.Errorf("the canonical string should be accepted (compiled: %t)", compiled)
+		}
+
+		expected := []used{{homer, "max power"}, {bart, "El_Barto"}, {bart, "el_barto"}}
+		if len(usages) != len(expected) {
+			t.Fatalf("expected %v, got %v (compiled: %t)", expected, usages, compiled)
+		}
+		for i := range expected {
+			if usages[i] != expected[i] {
+				t.Errorf("expected %v, got %v (compiled: %t)", expected[i], usages[i], compiled)
+			}
+		}
+
+		// Aliases are never marshalled.
+		b, err := meta.MarshalJSONHelper(bart)
+		if err != nil || string(b) != `"bart_simpson"` {
+			t.Errorf("expected \"bart_simpson\", got %s (%v)", string(b), err)
+		}
+	}
+}
+
+func TestValidateAliases(t *testing.T) {
+	testCases := []struct {
+		name          string
+		noStrings     bool
+		noJSONStrings bool
+		aliases       map[simpson][]string
+		jsonAliases   map[simpson][]string
+		expectedError error
+	}{
+		{
+			name:          "alias clashing with a string",
+			aliases:       map[simpson][]string{homer: {"Marge Simpson"}},
+			expectedError: goconstants.ErrAliasConflict,
+		},
+		{
+			name:          "alias clashing with another alias",
+			jsonAliases:   map[simpson][]string{homer: {"simpson"}, marge: {"simpson"}},
+			expectedError: goconstants.ErrAliasConflict,
+		},
+		{
+			name:          "alias of an unknown value",
+			aliases:       map[simpson][]string{999: {"Ned Flanders"}},
+			expectedError: goconstants.ErrAliasUnknownValue,
+		},
+		{
+			name:          "alias without Strings",
+			noStrings:     true,
+			aliases:       map[simpson][]string{999: {"Ned Flanders"}},
+			expectedError: goconstants.ErrAliasUnknownValue,
+		},
+		{
+			name:          "JSON alias without JSONStrings",
+			noJSONStrings: true,
+			jsonAliases:   map[simpson][]string{homer: {"Marge Simpson"}},
+			expectedError: goconstants.ErrAliasConflict,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			meta := cstMeta
+			meta.Aliases = testCase.aliases
+			meta.JSONAliases = testCase.jsonAliases
+			if testCase.noStrings {
+				meta.Strings = nil
+			}
+			if testCase.noJSONStrings {
+				meta.JSONStrings = nil
+			}
+
+			if err := meta.Validate(); !errors.Is(err, testCase.expectedError) {
+				t.Errorf("expected error %v, got %v", testCase.expectedError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+// The enumcheck command runs the enumcheck analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/samonzeweb/goconstants/analysis/enumcheck"
+)
+
+func main() { singlechecker.Main(enumcheck.Analyzer) }
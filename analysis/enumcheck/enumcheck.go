@@ -0,0 +1,104 @@
+// Package enumcheck defines an Analyzer checking that goconstants.Metadata
+// literals are complete.
+//
+// For each strings map (Strings, JSONStrings, DBStrings) of a Metadata
+// literal, it reports the constants of the type which are missing from the
+// map, and the keys which are not a declared constant or variable of the
+// type.
+//
+// Types without constants, like struct based constants, are not checked for
+// missing values.
+package enumcheck
+
+import (
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/samonzeweb/goconstants/analysis/enumscan"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "enumcheck",
+	Doc:      "check that goconstants.Metadata literals contain all constants of their type",
+	URL:      "https://pkg.go.dev/github.com/samonzeweb/goconstants/analysis/enumcheck",
+	Run:      run,
+	Requires: []*analysis.Analyzer{enumscan.Analyzer},
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	result := pass.ResultOf[enumscan.Analyzer].(*enumscan.Result)
+
+	for _, meta := range result.Metadata {
+		named, ok := meta.Type.(*types.Named)
+		if !ok || named.Obj().Pkg() == nil {
+			continue
+		}
+
+		constants := Constants(named)
+		for _, stringsMap := range meta.Maps {
+			if stringsMap.Lit == nil {
+				continue
+			}
+
+			checkKeys(pass, named, stringsMap)
+			checkMissing(pass, named, stringsMap, constants)
+		}
+	}
+
+	return nil, nil
+}
+
+// Constants returns the package level constants of the given type, declared
+// in the package of the type, sorted by name.
+func Constants(named *types.Named) []*types.Const {
+	var constants []*types.Const
+
+	scope := named.Obj().Pkg().Scope()
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if ok && name != "_" && types.Identical(c.Type(), named) {
+			constants = append(constants, c)
+		}
+	}
+
+	return constants
+}
+
+// checkKeys reports the keys which are not a constant or a variable of the
+// type.
+func checkKeys(pass *analysis.Pass, named *types.Named, stringsMap *enumscan.StringsMap) {
+	for _, key := range stringsMap.Keys {
+		switch obj := key.Object.(type) {
+		case *types.Const:
+			if types.Identical(obj.Type(), named) {
+				continue
+			}
+		case *types.Var:
+			if types.Identical(obj.Type(), named) && obj.Parent() == obj.Pkg().Scope() {
+				continue
+			}
+		}
+
+		pass.Reportf(key.Expr.Pos(), "%s key %s is not a declared %s constant",
+			stringsMap.Field, types.ExprString(key.Expr), named.Obj().Name())
+	}
+}
+
+// checkMissing reports the constants which are missing from the map.
+// Constants sharing the value of a key are not reported.
+func checkMissing(pass *analysis.Pass, named *types.Named, stringsMap *enumscan.StringsMap, constants []*types.Const) {
+	values := make(map[string]bool, len(stringsMap.Keys))
+	for _, key := range stringsMap.Keys {
+		if key.Value != nil {
+			values[key.Value.ExactString()] = true
+		}
+	}
+
+	for _, c := range constants {
+		if !values[c.Val().ExactString()] {
+			pass.Reportf(stringsMap.Lit.Pos(), "%s constant %s is missing from %s",
+				named.Obj().Name(), c.Name(), stringsMap.Field)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package enumcheck_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/samonzeweb/goconstants/analysis/enumcheck"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), enumcheck.Analyzer, "a")
+}
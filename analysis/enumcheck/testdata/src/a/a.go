@@ -0,0 +1,57 @@
+package a
+
+import "github.com/samonzeweb/goconstants"
+
+type GopherState int
+
+const (
+	GopherAsleep GopherState = 1 + iota
+	GopherEating
+	GopherCoding
+	GopherSleeping
+)
+
+// GopherDefault shares its value with GopherAsleep.
+const GopherDefault = GopherAsleep
+
+var metaGopherState = goconstants.Metadata[GopherState]{
+	Name: "GopherState",
+	Strings: map[GopherState]string{ // want `GopherState constant GopherSleeping is missing from Strings`
+		GopherAsleep: "Zzz",
+		GopherEating: "Miam",
+		GopherCoding: "Coding",
+		42:           "Unknown", // want `Strings key 42 is not a declared GopherState constant`
+	},
+	JSONStrings: map[GopherState]string{
+		GopherAsleep:   "sleeping",
+		GopherEating:   "eating",
+		GopherCoding:   "coding",
+		GopherSleeping: "sleeping",
+	},
+}.Compile()
+
+type LanguageEnum struct {
+	value int
+}
+
+var (
+	Go   = LanguageEnum{1}
+	Rust = LanguageEnum{2}
+)
+
+var metaLanguageEnum = goconstants.Metadata[LanguageEnum]{
+	Name: "LanguageEnum",
+	Strings: map[LanguageEnum]string{
+		Go:              "Go",
+		Rust:            "Rust",
+		LanguageEnum{3}: "Python", // want `Strings key LanguageEnum{…} is not a declared LanguageEnum constant`
+	},
+}
+
+var strings = map[GopherState]string{}
+
+// Maps which are not literals are not checked.
+var metaFromVariable = goconstants.Metadata[GopherState]{
+	Name:    "GopherState",
+	Strings: strings,
+}
@@ -0,0 +1,265 @@
+// Package enumscan finds the goconstants.Metadata composite literals of a
+// package. It's the discovery step shared by the goconstants analyzers.
+//
+// The Analyzer returns a *Result listing the literals and their strings
+// maps. It also exports an *EnumFact for each constant type having metadata.
+// As facts are private to the analyzer exporting them, the Result gives
+// access to the facts of the package and its dependencies (see
+// Result.Enum).
+package enumscan
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"reflect"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// Path of the goconstants package, and name of the Metadata type.
+const (
+	PackagePath  = "github.com/samonzeweb/goconstants"
+	MetadataName = "Metadata"
+)
+
+// StringsFields are the Metadata fields mapping values to strings.
+var StringsFields = []string{"Strings", "JSONStrings", "DBStrings"}
+
+var Analyzer = &analysis.Analyzer{
+	Name:       "enumscan",
+	Doc:        "find goconstants.Metadata composite literals",
+	URL:        "https://pkg.go.dev/github.com/samonzeweb/goconstants/analysis/enumscan",
+	Run:        run,
+	Requires:   []*analysis.Analyzer{inspect.Analyzer},
+	ResultType: reflect.TypeOf(new(Result)),
+	FactTypes:  []analysis.Fact{new(EnumFact)},
+}
+
+// Result is the result of the Analyzer.
+type Result struct {
+	// Metadata are the literals found, in source order.
+	Metadata []*Metadata
+	// enums contains the facts of the package and its dependencies.
+	enums map[types.Object]*EnumFact
+}
+
+// Enum returns the fact of a constant type, declared in the package or in
+// one of its dependencies.
+func (r *Result) Enum(t types.Type) (*EnumFact, bool) {
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok {
+		return nil, false
+	}
+
+	fact, ok := r.enums[named.Obj()]
+	return fact, ok
+}
+
+// Metadata is a goconstants.Metadata composite literal.
+type Metadata struct {
+	// Lit is the composite literal.
+	Lit *ast.CompositeLit
+	// Type is the constant type (the type argument of Metadata).
+	Type types.Type
+	// Maps are the strings maps set in the literal, in source order.
+	Maps []*StringsMap
+}
+
+// StringsMap is a strings field of a Metadata literal.
+type StringsMap struct {
+	// Field is the name of the field (see StringsFields).
+	Field string
+	// Value is the expression of the field.
+	Value ast.Expr
+	// Lit is the map composite literal, nil if the value is not a literal.
+	Lit *ast.CompositeLit
+	// Keys are the keys of the map literal.
+	Keys []*Key
+}
+
+// Key is a key of a strings map literal.
+type Key struct {
+	// Expr is the expression of the key.
+	Expr ast.Expr
+	// Object is the constant or variable referenced by the key, nil if the
+	// key is not an identifier.
+	Object types.Object
+	// Value is the value of a constant key, nil otherwise.
+	Value constant.Value
+}
+
+// EnumFact is exported for constant types having a Metadata literal with
+// constant keys.
+type EnumFact struct {
+	// Members are the values present in the Metadata, in source order.
+	// Only constant keys are recorded.
+	Members []Member
+}
+
+// Member is a constant value of an EnumFact.
+type Member struct {
+	// Name is the name of the constant.
+	Name string
+	// Value is the exact string of the constant value (see
+	// constant.Value.ExactString).
+	Value string
+}
+
+func (*EnumFact) AFact() {}
+
+func (f *EnumFact) String() string {
+	s := "enum("
+	for i, member := range f.Members {
+		if i > 0 {
+			s += ", "
+		}
+		s += member.Name
+	}
+	return s + ")"
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	result := &Result{}
+
+	nodeFilter := []ast.Node{(*ast.CompositeLit)(nil)}
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		lit := n.(*ast.CompositeLit)
+		typ, ok := MetadataType(pass.TypesInfo.TypeOf(lit))
+		if !ok {
+			return
+		}
+
+		result.Metadata = append(result.Metadata, scanMetadata(pass, lit, typ))
+	})
+
+	for _, meta := range result.Metadata {
+		exportFact(pass, meta)
+	}
+
+	result.enums = make(map[types.Object]*EnumFact)
+	for _, objectFact := range pass.AllObjectFacts() {
+		if fact, ok := objectFact.Fact.(*EnumFact); ok {
+			result.enums[objectFact.Object] = fact
+		}
+	}
+
+	return result, nil
+}
+
+// MetadataType checks if t is an instance of goconstants.Metadata, and
+// returns its type argument.
+func MetadataType(t types.Type) (types.Type, bool) {
+	named, ok := t.(*types.Named)
+	if !ok {
+		return nil, false
+	}
+
+	obj := named.Origin().Obj()
+	if obj.Pkg() == nil || obj.Pkg().Path() != PackagePath || obj.Name() != MetadataName {
+		return nil, false
+	}
+
+	if named.TypeArgs().Len() != 1 {
+		return nil, false
+	}
+
+	return named.TypeArgs().At(0), true
+}
+
+// scanMetadata collects the strings maps of a Metadata literal.
+func scanMetadata(pass *analysis.Pass, lit *ast.CompositeLit, typ types.Type) *Metadata {
+	meta := &Metadata{Lit: lit, Type: typ}
+
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+
+		field, ok := kv.Key.(*ast.Ident)
+		if !ok || !isStringsField(field.Name) {
+			continue
+		}
+
+		stringsMap := &StringsMap{Field: field.Name, Value: kv.Value}
+		if mapLit, ok := ast.Unparen(kv.Value).(*ast.CompositeLit); ok {
+			stringsMap.Lit = mapLit
+			stringsMap.Keys = scanKeys(pass, mapLit)
+		}
+		meta.Maps = append(meta.Maps, stringsMap)
+	}
+
+	return meta
+}
+
+// scanKeys returns the keys of a map literal.
+func scanKeys(pass *analysis.Pass, lit *ast.CompositeLit) []*Key {
+	keys := make([]*Key, 0, len(lit.Elts))
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+
+		key := &Key{Expr: kv.Key, Value: pass.TypesInfo.Types[kv.Key].Value}
+		switch expr := ast.Unparen(kv.Key).(type) {
+		case *ast.Ident:
+			key.Object = pass.TypesInfo.Uses[expr]
+		case *ast.SelectorExpr:
+			key.Object = pass.TypesInfo.Uses[expr.Sel]
+		}
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// exportFact exports an EnumFact for the constant type of a Metadata if the
+// type is declared in the current package.
+func exportFact(pass *analysis.Pass, meta *Metadata) {
+	named, ok := meta.Type.(*types.Named)
+	if !ok || named.Obj().Pkg() != pass.Pkg {
+		return
+	}
+
+	// The fact describes the values accepted by the Metadata, which are the
+	// keys of its first map having a literal.
+	for _, stringsMap := range meta.Maps {
+		if stringsMap.Lit == nil {
+			continue
+		}
+
+		fact := &EnumFact{}
+		for _, key := range stringsMap.Keys {
+			if key.Value == nil {
+				continue
+			}
+
+			name := key.Value.ExactString()
+			if key.Object != nil {
+				name = key.Object.Name()
+			}
+			fact.Members = append(fact.Members, Member{Name: name, Value: key.Value.ExactString()})
+		}
+		if len(fact.Members) > 0 {
+			pass.ExportObjectFact(named.Obj(), fact)
+		}
+
+		return
+	}
+}
+
+// isStringsField checks if name is one of StringsFields.
+func isStringsField(name string) bool {
+	for _, field := range StringsFields {
+		if name == field {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,27 @@
+package enumscan_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/samonzeweb/goconstants/analysis/enumscan"
+)
+
+func TestAnalyzer(t *testing.T) {
+	results := analysistest.Run(t, analysistest.TestData(), enumscan.Analyzer, "a")
+
+	result := results[0].Result.(*enumscan.Result)
+	if len(result.Metadata) != 2 {
+		t.Fatalf("expected 2 metadata, got %d", len(result.Metadata))
+	}
+
+	meta := result.Metadata[0]
+	if meta.Type.String() != "a.GopherState" {
+		t.Errorf("expected a.GopherState, got %s", meta.Type)
+	}
+
+	if len(meta.Maps) != 1 || meta.Maps[0].Field != "Strings" || len(meta.Maps[0].Keys) != 3 {
+		t.Errorf("unexpected strings maps %#v", meta.Maps)
+	}
+}
@@ -0,0 +1,31 @@
+package a
+
+import "github.com/samonzeweb/goconstants"
+
+type GopherState int // want GopherState:"enum\\(GopherAsleep, GopherEating, GopherCoding\\)"
+
+const (
+	GopherAsleep GopherState = 1 + iota
+	GopherEating
+	GopherCoding
+)
+
+var metaGopherState = goconstants.Metadata[GopherState]{
+	Name: "GopherState",
+	Strings: map[GopherState]string{
+		GopherAsleep: "Zzz",
+		GopherEating: "Miam",
+		GopherCoding: "Coding",
+	},
+}
+
+type LanguageEnum struct {
+	value int
+}
+
+var Go = LanguageEnum{1}
+
+var metaLanguageEnum = goconstants.Metadata[LanguageEnum]{
+	Name:    "LanguageEnum",
+	Strings: map[LanguageEnum]string{Go: "Go"},
+}
@@ -0,0 +1,10 @@
+// The enumswitch command runs the enumswitch analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/samonzeweb/goconstants/analysis/enumswitch"
+)
+
+func main() { singlechecker.Main(enumswitch.Analyzer) }
@@ -0,0 +1,135 @@
+// Package enumswitch defines an Analyzer checking that switch statements on
+// goconstants types handle all values.
+//
+// The values of a type are the constant keys of its goconstants.Metadata
+// literal, found by the enumscan analyzer, in the same package or in a
+// dependency.
+//
+// A switch statement is ignored if it's preceded by, or on the same line
+// as, a //goconstants:nonexhaustive comment.
+// By default a switch having a default case is still checked, use the
+// -default-signifies-exhaustive flag to change this behavior.
+package enumswitch
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"github.com/samonzeweb/goconstants/analysis/enumscan"
+)
+
+// IgnoreDirective is the comment disabling the check of a switch statement.
+const IgnoreDirective = "//goconstants:nonexhaustive"
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "enumswitch",
+	Doc:      "check that switch statements on goconstants types handle all values",
+	URL:      "https://pkg.go.dev/github.com/samonzeweb/goconstants/analysis/enumswitch",
+	Run:      run,
+	Requires: []*analysis.Analyzer{enumscan.Analyzer, inspect.Analyzer},
+}
+
+// defaultSignifiesExhaustive is set by the -default-signifies-exhaustive flag.
+var defaultSignifiesExhaustive bool
+
+func init() {
+	Analyzer.Flags.BoolVar(&defaultSignifiesExhaustive, "default-signifies-exhaustive", false,
+		"consider switch statements having a default case as exhaustive")
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	result := pass.ResultOf[enumscan.Analyzer].(*enumscan.Result)
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	ignored := ignoredLines(pass)
+
+	nodeFilter := []ast.Node{(*ast.SwitchStmt)(nil)}
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		stmt := n.(*ast.SwitchStmt)
+		if stmt.Tag == nil {
+			return
+		}
+
+		fact, ok := result.Enum(pass.TypesInfo.TypeOf(stmt.Tag))
+		if !ok {
+			return
+		}
+
+		position := pass.Fset.Position(stmt.Pos())
+		if ignored[lineKey{position.Filename, position.Line}] ||
+			ignored[lineKey{position.Filename, position.Line - 1}] {
+			return
+		}
+
+		handled, hasDefault := handledValues(pass, stmt)
+		if hasDefault && defaultSignifiesExhaustive {
+			return
+		}
+
+		var missing []string
+		for _, member := range fact.Members {
+			if !handled[member.Value] {
+				missing = append(missing, member.Name)
+			}
+		}
+
+		if len(missing) > 0 {
+			pass.Reportf(stmt.Pos(), "missing cases in switch of type %s: %s",
+				types.TypeString(pass.TypesInfo.TypeOf(stmt.Tag), types.RelativeTo(pass.Pkg)),
+				strings.Join(missing, ", "))
+		}
+	})
+
+	return nil, nil
+}
+
+// lineKey identifies a line of a file.
+type lineKey struct {
+	filename string
+	line     int
+}
+
+// ignoredLines returns the lines having an IgnoreDirective comment.
+func ignoredLines(pass *analysis.Pass) map[lineKey]bool {
+	ignored := make(map[lineKey]bool)
+	for _, file := range pass.Files {
+		for _, group := range file.Comments {
+			for _, comment := range group.List {
+				if strings.HasPrefix(comment.Text, IgnoreDirective) {
+					position := pass.Fset.Position(comment.Pos())
+					ignored[lineKey{position.Filename, position.Line}] = true
+				}
+			}
+		}
+	}
+
+	return ignored
+}
+
+// handledValues returns the constant values handled by the cases of a
+// switch statement, and whether the statement has a default case.
+func handledValues(pass *analysis.Pass, stmt *ast.SwitchStmt) (map[string]bool, bool) {
+	handled := make(map[string]bool)
+	hasDefault := false
+
+	for _, clause := range stmt.Body.List {
+		caseClause := clause.(*ast.CaseClause)
+		if caseClause.List == nil {
+			hasDefault = true
+			continue
+		}
+
+		for _, expr := range caseClause.List {
+			if value := pass.TypesInfo.Types[expr].Value; value != nil {
+				handled[value.ExactString()] = true
+			}
+		}
+	}
+
+	return handled, hasDefault
+}
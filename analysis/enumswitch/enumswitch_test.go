@@ -0,0 +1,31 @@
+package enumswitch_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/samonzeweb/goconstants/analysis/enumswitch"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), enumswitch.Analyzer, "a", "b")
+}
+
+func TestAnalyzerDefaultSignifiesExhaustive(t *testing.T) {
+	setFlag(t, "default-signifies-exhaustive", "true")
+
+	analysistest.Run(t, analysistest.TestData(), enumswitch.Analyzer, "defaults")
+}
+
+// setFlag sets a flag of the analyzer for the duration of the test.
+func setFlag(t *testing.T, name string, value string) {
+	previous := enumswitch.Analyzer.Flags.Lookup(name).Value.String()
+	if err := enumswitch.Analyzer.Flags.Set(name, value); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	t.Cleanup(func() {
+		enumswitch.Analyzer.Flags.Set(name, previous)
+	})
+}
@@ -0,0 +1,71 @@
+package a
+
+import "github.com/samonzeweb/goconstants"
+
+type GopherState int
+
+const (
+	GopherAsleep GopherState = 1 + iota
+	GopherEating
+	GopherCoding
+)
+
+var metaGopherState = goconstants.Metadata[GopherState]{
+	Name: "GopherState",
+	Strings: map[GopherState]string{
+		GopherAsleep: "Zzz",
+		GopherEating: "Miam",
+		GopherCoding: "Coding",
+	},
+}
+
+func exhaustive(gs GopherState) string {
+	switch gs {
+	case GopherAsleep:
+		return "asleep"
+	case GopherEating, GopherCoding:
+		return "awake"
+	}
+	return ""
+}
+
+func missing(gs GopherState) string {
+	switch gs { // want `missing cases in switch of type GopherState: GopherEating, GopherCoding`
+	case GopherAsleep:
+		return "asleep"
+	}
+	return ""
+}
+
+func withDefault(gs GopherState) string {
+	switch gs { // want `missing cases in switch of type GopherState: GopherCoding`
+	case GopherAsleep, GopherEating:
+		return "not coding"
+	default:
+		return "coding"
+	}
+}
+
+func ignored(gs GopherState) string {
+	//goconstants:nonexhaustive
+	switch gs {
+	case GopherAsleep:
+		return "asleep"
+	}
+
+	switch gs { //goconstants:nonexhaustive
+	case GopherAsleep:
+		return "asleep"
+	}
+	return ""
+}
+
+type notAnEnum int
+
+func notChecked(n notAnEnum) string {
+	switch n {
+	case 1:
+		return "one"
+	}
+	return ""
+}
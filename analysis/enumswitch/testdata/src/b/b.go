@@ -0,0 +1,11 @@
+package b
+
+import "a"
+
+func missing(gs a.GopherState) string {
+	switch gs { // want `missing cases in switch of type a.GopherState: GopherAsleep`
+	case a.GopherEating, a.GopherCoding:
+		return "awake"
+	}
+	return ""
+}
@@ -0,0 +1,20 @@
+package defaults
+
+import "a"
+
+func withDefault(gs a.GopherState) string {
+	switch gs {
+	case a.GopherAsleep:
+		return "asleep"
+	default:
+		return "awake"
+	}
+}
+
+func missing(gs a.GopherState) string {
+	switch gs { // want `missing cases in switch of type a.GopherState: GopherEating, GopherCoding`
+	case a.GopherAsleep:
+		return "asleep"
+	}
+	return ""
+}
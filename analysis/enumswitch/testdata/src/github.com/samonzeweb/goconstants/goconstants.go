@@ -0,0 +1,11 @@
+// Package goconstants is a stub of the real package.
+package goconstants
+
+type Metadata[T comparable] struct {
+	Name        string
+	Strings     map[T]string
+	JSONStrings map[T]string
+	DBStrings   map[T]string
+}
+
+func (meta Metadata[T]) Compile() Metadata[T] { return meta }
@@ -0,0 +1,410 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"strings"
+	"unicode"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Config contains the parameters of the generation.
+type Config struct {
+	// Types are the names of the constant types.
+	Types []string
+	// TrimPrefix is removed from the constant names before applying rules.
+	TrimPrefix string
+	// StringRule is the rule deriving Strings, JSONRule and DBRule are
+	// optional.
+	StringRule string
+	JSONRule   string
+	DBRule     string
+	// Methods are the names of the generated wrapping methods.
+	Methods []string
+	// Args are the command line arguments, written in the header.
+	Args []string
+}
+
+// directivePrefix starts the comments setting explicitly a string.
+const directivePrefix = "goconstants:"
+
+// Kinds of strings, used by the directives.
+const (
+	stringKind = "string"
+	jsonKind   = "json"
+	dbKind     = "db"
+)
+
+// deprecatedDirective marks a constant as deprecated, followed by the
+// deprecation message.
+const deprecatedDirective = "deprecated"
+
+// replacementDirective marks a constant as deprecated, followed by the name
+// of the constant replacing it.
+const replacementDirective = "replacement"
+
+// constant is a constant of a generated type.
+type constant struct {
+	name        string
+	strings     map[string]string
+	deprecated  bool
+	message     string
+	replacement string
+}
+
+// Generate returns the formatted source code of the generated file for the
+// package in the given directory.
+func Generate(dir string, config Config) ([]byte, error) {
+	for _, name := range config.Methods {
+		if _, ok := methodTemplates[name]; !ok {
+			return nil, fmt.Errorf("unknown method %q", name)
+		}
+	}
+
+	pkg, err := loadPackage(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by \"goconstants %s\"; DO NOT EDIT.\n\n", strings.Join(config.Args, " "))
+	fmt.Fprintf(&buf, "package %s\n\n", pkg.Name)
+	fmt.Fprintf(&buf, "import (\n")
+	if contains(config.Methods, "Value") {
+		fmt.Fprintf(&buf, "\t\"database/sql/driver\"\n\n")
+	}
+	fmt.Fprintf(&buf, "\t\"github.com/samonzeweb/goconstants\"\n)\n")
+
+	for _, typeName := range config.Types {
+		constants, err := findConstants(pkg, typeName)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := checkReplacements(constants); err != nil {
+			return nil, fmt.Errorf("type %s: %w", typeName, err)
+		}
+
+		if err := deriveStrings(constants, config); err != nil {
+			return nil, fmt.Errorf("type %s: %w", typeName, err)
+		}
+
+		writeMetadata(&buf, typeName, constants)
+		writeMethods(&buf, typeName, config.Methods)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+
+	return src, nil
+}
+
+// loadPackage loads and type checks the package in the given directory.
+func loadPackage(dir string) (*packages.Package, error) {
+	config := &packages.Config{
+		Mode: packages.NeedName | packages.NeedTypes | packages.NeedTypesInfo | packages.NeedSyntax,
+		Dir:  dir,
+	}
+
+	pkgs, err := packages.Load(config, ".")
+	if err != nil {
+		return nil, fmt.Errorf("loading package: %w", err)
+	}
+
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("%d packages found in %s", len(pkgs), dir)
+	}
+
+	pkg := pkgs[0]
+	if len(pkg.Errors) > 0 {
+		return nil, fmt.Errorf("loading package: %v", pkg.Errors[0])
+	}
+
+	return pkg, nil
+}
+
+// findConstants returns the constants of the given type in declaration
+// order, with the strings set by directives.
+// Constants sharing the value of a previous constant are ignored.
+func findConstants(pkg *packages.Package, typeName string) ([]*constant, error) {
+	obj, ok := pkg.Types.Scope().Lookup(typeName).(*types.TypeName)
+	if !ok {
+		return nil, fmt.Errorf("type %s not found", typeName)
+	}
+
+	var constants []*constant
+	values := make(map[string]bool)
+
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.CONST {
+				continue
+			}
+
+			for _, spec := range genDecl.Specs {
+				valueSpec := spec.(*ast.ValueSpec)
+				for _, ident := range valueSpec.Names {
+					c, ok := pkg.TypesInfo.Defs[ident].(*types.Const)
+					if !ok || ident.Name == "_" || !types.Identical(c.Type(), obj.Type()) {
+						continue
+					}
+
+					value := c.Val().ExactString()
+					if values[value] {
+						continue
+					}
+					values[value] = true
+
+					current := &constant{name: ident.Name}
+					if err := parseDirectives(valueSpec, current); err != nil {
+						return nil, fmt.Errorf("constant %s: %w", ident.Name, err)
+					}
+
+					if (len(current.strings) > 0 || current.deprecated) && len(valueSpec.Names) > 1 {
+						return nil, fmt.Errorf("constant %s: directives are not allowed when several constants are declared on the same line", ident.Name)
+					}
+
+					constants = append(constants, current)
+				}
+			}
+		}
+	}
+
+	if len(constants) == 0 {
+		return nil, fmt.Errorf("no constants found for type %s", typeName)
+	}
+
+	return constants, nil
+}
+
+// parseDirectives sets the strings and the deprecation defined by directives
+// in the comments of a constant declaration.
+func parseDirectives(spec *ast.ValueSpec, c *constant) error {
+	c.strings = make(map[string]string)
+
+	for _, group := range []*ast.CommentGroup{spec.Doc, spec.Comment} {
+		if group == nil {
+			continue
+		}
+
+		for _, comment := range group.List {
+			text, ok := strings.CutPrefix(comment.Text, "//"+directivePrefix)
+			if !ok {
+				continue
+			}
+
+			kind, value, _ := strings.Cut(text, " ")
+			switch kind {
+			case stringKind, jsonKind, dbKind:
+				c.strings[kind] = strings.TrimSpace(value)
+			case deprecatedDirective:
+				c.deprecated = true
+				c.message = strings.TrimSpace(value)
+			case replacementDirective:
+				c.deprecated = true
+				c.replacement = strings.TrimSpace(value)
+			default:
+				return fmt.Errorf("unknown directive %q", directivePrefix+kind)
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkReplacements checks that the replacements set by directives are
+// constants of the type.
+func checkReplacements(constants []*constant) error {
+	names := make(map[string]bool, len(constants))
+	for _, c := range constants {
+		names[c.name] = true
+	}
+
+	for _, c := range constants {
+		if c.replacement != "" && !names[c.replacement] {
+			return fmt.Errorf("constant %s: the replacement %q is not a constant of the type", c.name, c.replacement)
+		}
+	}
+
+	return nil
+}
+
+// deriveStrings sets the strings not defined by directives, applying the
+// configured rules.
+// Without rule, the JSON and database strings fall back to Strings if any
+// directive needs them.
+func deriveStrings(constants []*constant, config Config) error {
+	rules := map[string]string{
+		stringKind: config.StringRule,
+		jsonKind:   config.JSONRule,
+		dbKind:     config.DBRule,
+	}
+
+	for _, kind := range []string{jsonKind, dbKind} {
+		if rules[kind] == "" && !anyDirective(constants, kind) {
+			delete(rules, kind)
+		}
+	}
+
+	for _, kind := range []string{stringKind, jsonKind, dbKind} {
+		rule, ok := rules[kind]
+		if !ok {
+			continue
+		}
+
+		for _, c := range constants {
+			if _, ok := c.strings[kind]; ok {
+				continue
+			}
+
+			if rule == "" {
+				c.strings[kind] = c.strings[stringKind]
+				continue
+			}
+
+			derived, err := derive(rule, c.name, config.TrimPrefix)
+			if err != nil {
+				return err
+			}
+			c.strings[kind] = derived
+		}
+	}
+
+	return nil
+}
+
+// anyDirective checks if a constant has a directive of the given kind.
+func anyDirective(constants []*constant, kind string) bool {
+	for _, c := range constants {
+		if _, ok := c.strings[kind]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// anyDeprecated checks if a constant is deprecated.
+func anyDeprecated(constants []*constant) bool {
+	for _, c := range constants {
+		if c.deprecated {
+			return true
+		}
+	}
+
+	return false
+}
+
+// writeMetadata writes the Metadata variable of a type. The values are
+// ordered as the constants declarations.
+func writeMetadata(buf *bytes.Buffer, typeName string, constants []*constant) {
+	fmt.Fprintf(buf, "\n// %s are metadata of %s.\n", metadataName(typeName), typeName)
+	fmt.Fprintf(buf, "var %s = goconstants.Metadata[%s]{\n", metadataName(typeName), typeName)
+	fmt.Fprintf(buf, "\tName: %q,\n", typeName)
+
+	fields := []struct {
+		name string
+		kind string
+	}{
+		{name: "Strings", kind: stringKind},
+		{name: "JSONStrings", kind: jsonKind},
+		{name: "DBStrings", kind: dbKind},
+	}
+	for _, field := range fields {
+		if _, ok := constants[0].strings[field.kind]; !ok {
+			continue
+		}
+
+		fmt.Fprintf(buf, "\t%s: map[%s]string{\n", field.name, typeName)
+		for _, c := range constants {
+			fmt.Fprintf(buf, "\t\t%s: %q,\n", c.name, c.strings[field.kind])
+		}
+		fmt.Fprintf(buf, "\t},\n")
+	}
+
+	fmt.Fprintf(buf, "\tOrder: []%s{\n", typeName)
+	for _, c := range constants {
+		fmt.Fprintf(buf, "\t\t%s,\n", c.name)
+	}
+	fmt.Fprintf(buf, "\t},\n")
+
+	if anyDeprecated(constants) {
+		fmt.Fprintf(buf, "\tDeprecations: map[%s]goconstants.Deprecation[%s]{\n", typeName, typeName)
+		for _, c := range constants {
+			switch {
+			case c.replacement != "":
+				fmt.Fprintf(buf, "\t\t%s: {Message: %q, Replacement: %s, HasReplacement: true},\n", c.name, c.message, c.replacement)
+			case c.deprecated:
+				fmt.Fprintf(buf, "\t\t%s: {Message: %q},\n", c.name, c.message)
+			}
+		}
+		fmt.Fprintf(buf, "\t},\n")
+	}
+
+	fmt.Fprintf(buf, "}.Compile()\n")
+}
+
+// writeMethods writes the selected methods wrapping the helpers, in the
+// order of methodNames.
+func writeMethods(buf *bytes.Buffer, typeName string, methods []string) {
+	replacer := strings.NewReplacer(
+		"$recv", receiverName(typeName),
+		"$type", typeName,
+		"$meta", metadataName(typeName),
+	)
+
+	for _, name := range methodNames {
+		if contains(methods, name) {
+			buf.WriteString("\n")
+			replacer.WriteString(buf, methodTemplates[name])
+		}
+	}
+}
+
+// metadataName returns the name of the Metadata variable of a type.
+func metadataName(typeName string) string {
+	return "meta" + upperFirst(typeName)
+}
+
+// receiverName returns the receiver name of the methods of a type, made of
+// the lowered upper case letters of the type name (ie "gs" for GopherState).
+func receiverName(typeName string) string {
+	var name []rune
+	for _, r := range typeName {
+		if unicode.IsUpper(r) {
+			name = append(name, unicode.ToLower(r))
+		}
+	}
+
+	if len(name) == 0 {
+		return strings.ToLower(typeName[:1])
+	}
+
+	return string(name)
+}
+
+// upperFirst returns the string with its first letter in upper case.
+func upperFirst(s string) string {
+	runes := []rune(s)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// contains checks if a string is present in a slice.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
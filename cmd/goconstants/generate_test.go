@@ -0,0 +1,80 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestGenerate(t *testing.T) {
+	config := Config{
+		Types:      []string{"GopherState"},
+		TrimPrefix: "Gopher",
+		StringRule: "title",
+		JSONRule:   "snake",
+		Methods:    []string{"String", "IsValid", "MarshalJSON", "UnmarshalJSON", "Value"},
+		Args:       []string{"-type=GopherState", "-trimprefix=Gopher", "-string=title", "-json=snake"},
+	}
+
+	src, err := Generate(filepath.Join("testdata", "gopher"), config)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	golden := filepath.Join("testdata", "gopherstate_goconstants.golden")
+	if *update {
+		if err := os.WriteFile(golden, src, 0o644); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+
+	expected, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if string(src) != string(expected) {
+		t.Errorf("generated code differs from %s:\n%s", golden, src)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config Config
+	}{
+		{
+			name:   "unknown type",
+			config: Config{Types: []string{"DragonState"}, StringRule: "identity"},
+		},
+		{
+			name:   "type without constants",
+			config: Config{Types: []string{"Empty"}, StringRule: "identity"},
+		},
+		{
+			name:   "unknown replacement",
+			config: Config{Types: []string{"Broken"}, StringRule: "identity"},
+		},
+		{
+			name:   "unknown rule",
+			config: Config{Types: []string{"GopherState"}, StringRule: "pascal"},
+		},
+		{
+			name:   "unknown method",
+			config: Config{Types: []string{"GopherState"}, StringRule: "identity", Methods: []string{"GoString"}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := Generate(filepath.Join("testdata", "gopher"), testCase.config)
+
+			if err == nil {
+				t.Errorf("expected an error, got none")
+			}
+		})
+	}
+}
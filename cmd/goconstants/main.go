@@ -0,0 +1,106 @@
+// Command goconstants generates the Metadata variable of a constant type and
+// the methods wrapping its helpers.
+//
+// Usage in a go:generate directive:
+//
+//	//go:generate goconstants -type=GopherState -trimprefix=Gopher -json=snake
+//
+// The constants of the given type are read from the package in the current
+// directory. The strings are derived from the constant names, after removing
+// the prefix given with -trimprefix, using one of the following rules:
+//
+//	identity   JokingAboutJS (default)
+//	title      Joking About JS
+//	snake      joking_about_js
+//	kebab      joking-about-js
+//	screaming  JOKING_ABOUT_JS
+//	camel      jokingAboutJS
+//	lower      jokingaboutjs
+//	upper      JOKINGABOUTJS
+//
+// A string could be set explicitly with a directive in the doc or line
+// comment of a constant:
+//
+//	GopherJokingAboutJS //goconstants:string Lol
+//
+// The directives are goconstants:string, goconstants:json and goconstants:db,
+// one per line.
+//
+// A constant is marked as deprecated with the goconstants:deprecated
+// directive, followed by an optional message, and with the
+// goconstants:replacement directive, followed by the constant replacing it:
+//
+//	//goconstants:deprecated jokes are not a state
+//	//goconstants:replacement GopherCoding
+//	GopherJokingAboutJS
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	typeNames  = flag.String("type", "", "comma-separated list of type names; must be set")
+	output     = flag.String("output", "", "output file name; default <type>_goconstants.go")
+	trimPrefix = flag.String("trimprefix", "", "prefix removed from the constant names")
+	stringRule = flag.String("string", "identity", "rule deriving Strings from the constant names")
+	jsonRule   = flag.String("json", "", "rule deriving JSONStrings from the constant names; none by default")
+	dbRule     = flag.String("db", "", "rule deriving DBStrings from the constant names; none by default")
+	methods    = flag.String("methods", strings.Join(defaultMethods, ","), "comma-separated list of generated methods")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage of goconstants:\n")
+	fmt.Fprintf(os.Stderr, "\tgoconstants [flags] -type T [directory]\n")
+	fmt.Fprintf(os.Stderr, "Flags:\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("goconstants: ")
+	flag.Usage = usage
+	flag.Parse()
+
+	if *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+
+	config := Config{
+		Types:      strings.Split(*typeNames, ","),
+		TrimPrefix: *trimPrefix,
+		StringRule: *stringRule,
+		JSONRule:   *jsonRule,
+		DBRule:     *dbRule,
+		Methods:    strings.Split(*methods, ","),
+		Args:       os.Args[1:],
+	}
+
+	src, err := Generate(dir, config)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	outputName := *output
+	if outputName == "" {
+		outputName = strings.ToLower(config.Types[0]) + "_goconstants.go"
+	}
+	if !filepath.IsAbs(outputName) {
+		outputName = filepath.Join(dir, outputName)
+	}
+
+	if err := os.WriteFile(outputName, src, 0o644); err != nil {
+		log.Fatalf("writing output: %s", err)
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+// defaultMethods are the methods generated when the -methods flag is not set.
+var defaultMethods = []string{"String", "ToString", "IsValid", "MarshalJSON", "UnmarshalJSON"}
+
+// methodNames are the names of all methods which could be generated, in the
+// order of generation.
+var methodNames = []string{
+	"String",
+	"ToString",
+	"IsValid",
+	"MarshalJSON",
+	"UnmarshalJSON",
+	"MarshalText",
+	"UnmarshalText",
+	"Value",
+	"Scan",
+}
+
+// methodTemplates contains the source code of the methods. $recv, $type and
+// $meta are replaced by the receiver name, the type name and the Metadata
+// variable name.
+var methodTemplates = map[string]string{
+	"String": `// String returns a string representation of the constant.
+// It implements the fmt.Stringer interface.
+func ($recv $type) String() string {
+	return $meta.StringHelper($recv)
+}
+`,
+	"ToString": `// ToString returns string representation of the constant and
+// an error if the given value is unknown.
+func ($recv $type) ToString() (string, error) {
+	return $meta.ToStringHelper($recv)
+}
+`,
+	"IsValid": `// IsValid checks if the given constant is valid (known).
+func ($recv $type) IsValid() bool {
+	return $meta.IsValidHelper($recv)
+}
+`,
+	"MarshalJSON": `// MarshalJSON implements json.Marshaler
+func ($recv $type) MarshalJSON() ([]byte, error) {
+	return $meta.MarshalJSONHelper($recv)
+}
+`,
+	"UnmarshalJSON": `// UnmarshalJSON implements json.Unmarshaler
+func ($recv *$type) UnmarshalJSON(b []byte) error {
+	return $meta.UnmarshalJSONHelper(b, $recv)
+}
+`,
+	"MarshalText": `// MarshalText implements encoding.TextMarshaler
+func ($recv $type) MarshalText() ([]byte, error) {
+	return $meta.MarshalTextHelper($recv)
+}
+`,
+	"UnmarshalText": `// UnmarshalText implements encoding.TextUnmarshaler
+func ($recv *$type) UnmarshalText(b []byte) error {
+	return $meta.UnmarshalTextHelper(b, $recv)
+}
+`,
+	"Value": `// Value implements driver.Valuer
+func ($recv $type) Value() (driver.Value, error) {
+	return $meta.ValueHelper($recv)
+}
+`,
+	"Scan": `// Scan implements sql.Scanner
+func ($recv *$type) Scan(src any) error {
+	return $meta.ScanHelper(src, $recv)
+}
+`,
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// rules contains the functions deriving a string from the words of a
+// constant name.
+var rules = map[string]func(words []string) string{
+	"identity": func(words []string) string {
+		return strings.Join(words, "")
+	},
+	"title": func(words []string) string {
+		return strings.Join(words, " ")
+	},
+	"snake": func(words []string) string {
+		return strings.ToLower(strings.Join(words, "_"))
+	},
+	"kebab": func(words []string) string {
+		return strings.ToLower(strings.Join(words, "-"))
+	},
+	"screaming": func(words []string) string {
+		return strings.ToUpper(strings.Join(words, "_"))
+	},
+	"camel": func(words []string) string {
+		camel := make([]string, len(words))
+		for i, word := range words {
+			if i == 0 {
+				camel[i] = strings.ToLower(word)
+			} else {
+				camel[i] = word
+			}
+		}
+		return strings.Join(camel, "")
+	},
+	"lower": func(words []string) string {
+		return strings.ToLower(strings.Join(words, ""))
+	},
+	"upper": func(words []string) string {
+		return strings.ToUpper(strings.Join(words, ""))
+	},
+}
+
+// ruleNames returns the sorted names of the rules.
+func ruleNames() []string {
+	names := make([]string, 0, len(rules))
+	for name := range rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// derive applies the named rule to a constant name, after removing the
+// given prefix.
+func derive(rule string, name string, prefix string) (string, error) {
+	apply, ok := rules[rule]
+	if !ok {
+		return "", fmt.Errorf("unknown rule %q, expected one of %s", rule, strings.Join(ruleNames(), ", "))
+	}
+
+	return apply(splitWords(strings.TrimPrefix(name, prefix))), nil
+}
+
+// splitWords splits an identifier into words, using underscores and case
+// changes as boundaries. Acronyms are kept as a single word, ie
+// "JokingAboutJS" gives "Joking", "About" and "JS", and letters with
+// diacritics are kept, ie "ÉtatCafé" gives "État" and "Café".
+func splitWords(name string) []string {
+	var words []string
+	runes := []rune(name)
+	start := 0
+
+	for i := 0; i <= len(runes); i++ {
+		switch {
+		case i == len(runes):
+		case runes[i] == '_':
+		case i > start && isBoundary(runes, i):
+		default:
+			continue
+		}
+
+		if i > start {
+			words = append(words, string(runes[start:i]))
+		}
+
+		start = i
+		if i < len(runes) && runes[i] == '_' {
+			start = i + 1
+		}
+	}
+
+	return words
+}
+
+// isBoundary checks if a new word begins at position i.
+func isBoundary(runes []rune, i int) bool {
+	previous, current := runes[i-1], runes[i]
+	if !unicode.IsUpper(current) {
+		return false
+	}
+
+	if !unicode.IsUpper(previous) {
+		return true
+	}
+
+	// Last letter of an acronym followed by a word, ie the P in "JSONParser".
+	return i+1 < len(runes) && unicode.IsLower(runes[i+1])
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitWords(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected []string
+	}{
+		{input: "Asleep", expected: []string{"Asleep"}},
+		{input: "JokingAboutJS", expected: []string{"Joking", "About", "JS"}},
+		{input: "JSONParser", expected: []string{"JSON", "Parser"}},
+		{input: "Read_Write", expected: []string{"Read", "Write"}},
+		{input: "http2Server", expected: []string{"http2", "Server"}},
+		{input: "ÉtatCafé", expected: []string{"État", "Café"}},
+		{input: "", expected: nil},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			words := splitWords(testCase.input)
+
+			if !reflect.DeepEqual(words, testCase.expected) {
+				t.Errorf("expected %#v, got %#v", testCase.expected, words)
+			}
+		})
+	}
+}
+
+func TestDerive(t *testing.T) {
+	testCases := []struct {
+		rule     string
+		expected string
+	}{
+		{rule: "identity", expected: "JokingAboutJS"},
+		{rule: "title", expected: "Joking About JS"},
+		{rule: "snake", expected: "joking_about_js"},
+		{rule: "kebab", expected: "joking-about-js"},
+		{rule: "screaming", expected: "JOKING_ABOUT_JS"},
+		{rule: "camel", expected: "jokingAboutJS"},
+		{rule: "lower", expected: "jokingaboutjs"},
+		{rule: "upper", expected: "JOKINGABOUTJS"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.rule, func(t *testing.T) {
+			derived, err := derive(testCase.rule, "GopherJokingAboutJS", "Gopher")
+
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			if derived != testCase.expected {
+				t.Errorf("expected %s, got %s", testCase.expected, derived)
+			}
+		})
+	}
+
+	if _, err := derive("pascal", "GopherAsleep", ""); err == nil {
+		t.Errorf("expected an error for an unknown rule")
+	}
+}
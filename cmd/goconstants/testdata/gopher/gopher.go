@@ -0,0 +1,31 @@
+package gopher
+
+// GopherState is a constant type.
+type GopherState int
+
+const (
+	GopherAsleep GopherState = 1 + iota
+	GopherEating
+	// GopherCoding is the favorite state.
+	//goconstants:string Coding hard
+	GopherCoding
+	//goconstants:deprecated jokes are not a state
+	//goconstants:replacement GopherCoding
+	GopherJokingAboutJS //goconstants:json lol
+)
+
+// GopherDefault shares the value of GopherAsleep, it's ignored.
+const GopherDefault = GopherAsleep
+
+// Unrelated is not a GopherState.
+const Unrelated = 42
+
+// Empty has no constants.
+type Empty int
+
+// Broken is replaced by a constant of another type.
+type Broken int
+
+const (
+	BrokenOld Broken = 1 //goconstants:replacement Unrelated
+)
@@ -0,0 +1,87 @@
+package goconstants
+
+// index contains precomputed reverse lookups of a Metadata.
+type index[T comparable] struct {
+	reverses         map[Representation]map[string]T
+	aliases          map[Representation]map[string]T
+	values           []T
+	positions        map[T]int
+	numbers          map[int32]T
+	protoNames       map[T]string
+	protoUnspecified string
+	graphQLNames     map[T]string
+	graphQLProblems  map[T]error
+	flags            *flagLayout[T]
+}
+
+// Compile returns a copy of the Metadata with precomputed reverse lookups for
+// all representations. FromStringHelper, UnmarshalJSONHelper and the others
+// parsing helpers then run in constant time instead of scanning the strings.
+// The order of the values, the derived names and the bits of the flags are
+// also computed once (see Values).
+//
+// The maps must not be modified after the call, as the index would not
+// reflect the changes. Compile again if you need to.
+func (meta Metadata[T]) Compile() Metadata[T] {
+	meta.index = nil
+	idx := &index[T]{
+		reverses:         make(map[Representation]map[string]T, len(representations)),
+		aliases:          make(map[Representation]map[string]T, len(representations)),
+		values:           meta.Values(),
+		protoNames:       meta.getProtoNames(),
+		protoUnspecified: meta.protoUnspecified(),
+		graphQLNames:     meta.getGraphQLStrings(),
+		graphQLProblems:  meta.derivedGraphQLProblems(),
+	}
+
+	idx.positions = make(map[T]int, len(idx.values))
+	for i, v := range idx.values {
+		idx.positions[v] = i
+	}
+
+	if meta.ProtoNumbers != nil {
+		idx.numbers = make(map[int32]T, len(meta.ProtoNumbers))
+		for v, n := range meta.ProtoNumbers {
+			idx.numbers[n] = v
+		}
+	}
+
+	// The reverse lookups use the derived names stored in the index.
+	meta.index = idx
+	for _, r := range representations {
+		idx.reverses[r] = reverseMap(meta.getRepresentation(r), meta.Parsing)
+		idx.aliases[r] = reverseAliases(meta.getAliases(r), meta.Parsing)
+	}
+
+	if meta.Flags {
+		idx.flags = meta.newFlagLayout()
+	}
+
+	return meta
+}
+
+// IsCompiled reports whether the Metadata has precomputed reverse lookups.
+func (meta Metadata[T]) IsCompiled() bool {
+	return meta.index != nil
+}
+
+// reverse returns the reverse lookup of the given representation if the
+// index exists, nil otherwise.
+func (idx *index[T]) reverse(r Representation) map[string]T {
+	if idx == nil {
+		return nil
+	}
+
+	return idx.reverses[r]
+}
+
+// reverseMap builds a map from representations, transformed as specified by
+// the parse options, to constant values.
+func reverseMap[T comparable](strings map[T]string, opts ParseOptions) map[string]T {
+	reverse := make(map[string]T, len(strings))
+	for k, v := range strings {
+		reverse[opts.key(v)] = k
+	}
+
+	return reverse
+}
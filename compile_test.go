@@ -0,0 +1,99 @@
+package goconstants_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/samonzeweb/goconstants"
+)
+
+func TestCompile(t *testing.T) {
+	compiled := cstMeta.Compile()
+
+	if cstMeta.IsCompiled() {
+		t.Errorf("the original metadata should not be compiled")
+	}
+
+	if !compiled.IsCompiled() {
+		t.Errorf("the metadata should be compiled")
+	}
+
+	for value, representation := range cstMeta.Strings {
+		parsed, ok := compiled.FromStringHelper(representation)
+		if !ok || parsed != value {
+			t.Errorf("expected %v, got %v (%t)", value, parsed, ok)
+		}
+	}
+
+	for value, representation := range cstMeta.JSONStrings {
+		var parsed simpson
+		err := compiled.UnmarshalJSONHelper([]byte(`"`+representation+`"`), &parsed)
+		if err != nil || parsed != value {
+			t.Errorf("expected %v, got %v (%v)", value, parsed, err)
+		}
+	}
+
+	if _, ok := compiled.FromStringHelper("Ned Flanders"); ok {
+		t.Errorf("an unknown representation should be invalid")
+	}
+
+	var parsed simpson
+	err := compiled.UnmarshalJSONHelper([]byte(`"ned_flanders"`), &parsed)
+	if err == nil {
+		t.Errorf("expected an error, got none")
+	}
+}
+
+type benchmarkConstant int
+
+// benchmarkMetadata returns metadata having the given number of values.
+func benchmarkMetadata(size int) goconstants.Metadata[benchmarkConstant] {
+	meta := goconstants.Metadata[benchmarkConstant]{
+		Name:        "benchmarkConstant",
+		Strings:     make(map[benchmarkConstant]string, size),
+		JSONStrings: make(map[benchmarkConstant]string, size),
+	}
+	for i := 0; i < size; i++ {
+		meta.Strings[benchmarkConstant(i)] = fmt.Sprintf("Value %d", i)
+		meta.JSONStrings[benchmarkConstant(i)] = fmt.Sprintf("value_%d", i)
+	}
+
+	return meta
+}
+
+func BenchmarkFromStringHelper(b *testing.B) {
+	meta := benchmarkMetadata(128)
+	compiled := meta.Compile()
+
+	b.Run("scan", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			meta.FromStringHelper("Value 100")
+		}
+	})
+
+	b.Run("compiled", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			compiled.FromStringHelper("Value 100")
+		}
+	})
+}
+
+func BenchmarkUnmarshalJSONHelper(b *testing.B) {
+	meta := benchmarkMetadata(128)
+	compiled := meta.Compile()
+	input := []byte(`"value_100"`)
+
+	b.Run("scan", func(b *testing.B) {
+		var value benchmarkConstant
+		for i := 0; i < b.N; i++ {
+			meta.UnmarshalJSONHelper(input, &value)
+		}
+	})
+
+	b.Run("compiled", func(b *testing.B) {
+		var value benchmarkConstant
+		for i := 0; i < b.N; i++ {
+			compiled.UnmarshalJSONHelper(input, &value)
+		}
+	})
+}
@@ -0,0 +1,151 @@
+package goconstants
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Deprecation describes a deprecated value.
+type Deprecation[T comparable] struct {
+	// Message explains why the value is deprecated.
+	Message string
+	// Replacement is the value to use instead of the deprecated one, only
+	// if HasReplacement is true.
+	Replacement    T
+	HasReplacement bool
+}
+
+// DeprecationPolicy selects what happens when a deprecated value is
+// marshalled or parsed.
+type DeprecationPolicy int
+
+// Deprecation policies available in Metadata.
+const (
+	// AllowDeprecated uses deprecated values silently.
+	AllowDeprecated DeprecationPolicy = iota
+	// NotifyDeprecated uses deprecated values, and calls OnDeprecated.
+	NotifyDeprecated
+	// ReplaceDeprecated uses the replacements of deprecated values.
+	ReplaceDeprecated
+	// RejectDeprecated returns a DeprecatedValueError.
+	RejectDeprecated
+)
+
+// Errors about deprecations.
+var (
+	// ErrDeprecatedValue matches (with errors.Is) DeprecatedValueError.
+	ErrDeprecatedValue = errors.New("deprecated value")
+	// Errors returned by Validate.
+	ErrDeprecationUnknownValue = errors.New("a deprecation is defined for an unknown value")
+	ErrInvalidReplacement      = errors.New("invalid replacement of a deprecated value")
+	ErrUnknownPolicy           = errors.New("unknown deprecation policy")
+)
+
+// DeprecatedValueError is returned when a deprecated value is marshalled or
+// parsed with the RejectDeprecated policy.
+type DeprecatedValueError struct {
+	// Enum is the name of the constant type.
+	Enum string
+	// Value is the deprecated value.
+	Value any
+	// Message explains why the value is deprecated.
+	Message string
+}
+
+// Error implements the error interface.
+func (e *DeprecatedValueError) Error() string {
+	message := fmt.Sprintf("deprecated %s value: %#v", e.Enum, e.Value)
+	if e.Message != "" {
+		message += " (" + e.Message + ")"
+	}
+
+	return message
+}
+
+// Is allows errors.Is to match ErrDeprecatedValue.
+func (e *DeprecatedValueError) Is(target error) bool {
+	return target == ErrDeprecatedValue
+}
+
+// IsDeprecatedHelper checks if a given constant is deprecated.
+func (meta Metadata[T]) IsDeprecatedHelper(v T) bool {
+	_, ok := meta.Deprecations[v]
+	return ok
+}
+
+// DeprecationHelper returns the deprecation of a given constant, and a
+// boolean indicating if the constant is deprecated.
+func (meta Metadata[T]) DeprecationHelper(v T) (Deprecation[T], bool) {
+	deprecation, ok := meta.Deprecations[v]
+	return deprecation, ok
+}
+
+// marshalled applies MarshalDeprecated to a value about to be marshalled.
+func (meta Metadata[T]) marshalled(v T) (T, error) {
+	return meta.applyPolicy(v, meta.MarshalDeprecated)
+}
+
+// parsed applies ParseDeprecated to a value just parsed.
+func (meta Metadata[T]) parsed(v T) (T, error) {
+	return meta.applyPolicy(v, meta.ParseDeprecated)
+}
+
+// applyPolicy returns the value to use instead of v, according to the
+// given policy.
+func (meta Metadata[T]) applyPolicy(v T, policy DeprecationPolicy) (T, error) {
+	deprecation, ok := meta.Deprecations[v]
+	if !ok {
+		if meta.Flags && len(meta.Deprecations) > 0 {
+			return meta.applyFlagsPolicy(v, policy)
+		}
+
+		return v, nil
+	}
+
+	switch policy {
+	case NotifyDeprecated:
+		if meta.OnDeprecated != nil {
+			meta.OnDeprecated(v, deprecation)
+		}
+	case ReplaceDeprecated:
+		if deprecation.HasReplacement {
+			return deprecation.Replacement, nil
+		}
+	case RejectDeprecated:
+		return v, &DeprecatedValueError{Enum: meta.Name, Value: v, Message: deprecation.Message}
+	}
+
+	return v, nil
+}
+
+// deprecationsProblems returns the problems of the deprecations: unknown
+// values, unknown policies and invalid replacements.
+func (meta Metadata[T]) deprecationsProblems() []error {
+	var problems []error
+
+	for _, policy := range []DeprecationPolicy{meta.MarshalDeprecated, meta.ParseDeprecated} {
+		if policy < AllowDeprecated || policy > RejectDeprecated {
+			problems = append(problems, fmt.Errorf("%w: %d", ErrUnknownPolicy, policy))
+		}
+	}
+
+	replace := meta.MarshalDeprecated == ReplaceDeprecated || meta.ParseDeprecated == ReplaceDeprecated
+	for _, v := range sortedKeys(meta.Deprecations) {
+		deprecation := meta.Deprecations[v]
+		switch {
+		case !meta.IsValidHelper(v):
+			problems = append(problems, fmt.Errorf("%w: %#v", ErrDeprecationUnknownValue, v))
+		case deprecation.HasReplacement && !meta.IsValidHelper(deprecation.Replacement):
+			problems = append(problems, fmt.Errorf("%w: %#v is replaced by the unknown value %#v",
+				ErrInvalidReplacement, v, deprecation.Replacement))
+		case deprecation.HasReplacement && meta.IsDeprecatedHelper(deprecation.Replacement):
+			problems = append(problems, fmt.Errorf("%w: %#v is replaced by the deprecated value %#v",
+				ErrInvalidReplacement, v, deprecation.Replacement))
+		case !deprecation.HasReplacement && replace:
+			problems = append(problems, fmt.Errorf("%w: %#v has no replacement but the policy is to replace it",
+				ErrInvalidReplacement, v))
+		}
+	}
+
+	return problems
+}
@@ -0,0 +1,228 @@
+package goconstants_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/samonzeweb/goconstants"
+)
+
+// deprecatedMeta deprecates maggie, replaced by lisa, and bart, without
+// replacement.
+func deprecatedMeta() goconstants.Metadata[simpson] {
+	meta := cstMeta
+	meta.Deprecations = map[simpson]goconstants.Deprecation[simpson]{
+		maggie: {Message: "too young", Replacement: lisa, HasReplacement: true},
+		bart:   {Message: "eat my shorts"},
+	}
+
+	return meta
+}
+
+func TestIsDeprecatedHelper(t *testing.T) {
+	meta := deprecatedMeta()
+
+	testCases := []struct {
+		input    simpson
+		expected bool
+	}{
+		{input: homer, expected: false},
+		{input: bart, expected: true},
+		{input: maggie, expected: true},
+		{input: 999, expected: false},
+	}
+
+	for _, testCase := range testCases {
+		if deprecated := meta.IsDeprecatedHelper(testCase.input); deprecated != testCase.expected {
+			t.Errorf("expected %t for %v, got %t", testCase.expected, testCase.input, deprecated)
+		}
+	}
+
+	deprecation, ok := meta.DeprecationHelper(maggie)
+	if !ok || deprecation.Message != "too young" || deprecation.Replacement != lisa {
+		t.Errorf("unexpected deprecation %v (%t)", deprecation, ok)
+	}
+
+	// Deprecated values are still valid.
+	if !meta.IsValidHelper(maggie) {
+		t.Errorf("deprecated values should be valid")
+	}
+}
+
+func TestMarshalDeprecated(t *testing.T) {
+	testCases := []struct {
+		name          string
+		policy        goconstants.DeprecationPolicy
+		input         simpson
+		expected      string
+		expectedError error
+	}{
+		{
+			name:     "allow",
+			policy:   goconstants.AllowDeprecated,
+			input:    maggie,
+			expected: `"maggie_simpson"`,
+		},
+		{
+			name:     "notify",
+			policy:   goconstants.NotifyDeprecated,
+			input:    maggie,
+			expected: `"maggie_simpson"`,
+		},
+		{
+			name:     "replace",
+			policy:   goconstants.ReplaceDeprecated,
+			input:    maggie,
+			expected: `"lisa_simpson"`,
+		},
+		{
+			name:          "reject",
+			policy:        goconstants.RejectDeprecated,
+			input:         maggie,
+			expectedError: goconstants.ErrDeprecatedValue,
+		},
+		{
+			name:     "reject not deprecated",
+			policy:   goconstants.RejectDeprecated,
+			input:    homer,
+			expected: `"homer_simpson"`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var notified []simpson
+			meta := deprecatedMeta()
+			delete(meta.Deprecations, bart)
+			meta.MarshalDeprecated = testCase.policy
+			meta.OnDeprecated = func(v simpson, deprecation goconstants.Deprecation[simpson]) {
+				notified = append(notified, v)
+			}
+
+			if err := meta.Validate(); err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			b, err := meta.MarshalJSONHelper(testCase.input)
+			if !errors.Is(err, testCase.expectedError) {
+				t.Fatalf("expected error %v, got %v", testCase.expectedError, err)
+			}
+			if err == nil && string(b) != testCase.expected {
+				t.Errorf("expected %s, got %s", testCase.expected, string(b))
+			}
+
+			if testCase.policy == goconstants.NotifyDeprecated && (len(notified) != 1 || notified[0] != maggie) {
+				t.Errorf("expected %v to be notified, got %v", maggie, notified)
+			}
+			if testCase.policy != goconstants.NotifyDeprecated && len(notified) != 0 {
+				t.Errorf("unexpected notification %v", notified)
+			}
+		})
+	}
+}
+
+func TestParseDeprecated(t *testing.T) {
+	meta := deprecatedMeta()
+	delete(meta.Deprecations, bart)
+	meta.ParseDeprecated = goconstants.ReplaceDeprecated
+	meta = meta.Compile()
+
+	var value simpson
+	if err := meta.UnmarshalJSONHelper([]byte(`"maggie_simpson"`), &value); err != nil || value != lisa {
+		t.Errorf("expected %v, got %v (%v)", lisa, value, err)
+	}
+
+	if value, ok := meta.FromStringHelper("Maggie Simpson"); !ok || value != lisa {
+		t.Errorf("expected %v, got %v (%t)", lisa, value, ok)
+	}
+
+	// Marshalling is not affected by the parse policy.
+	if s, err := meta.MarshalTextHelper(maggie); err != nil || string(s) != "maggie_simpson" {
+		t.Errorf("expected maggie_simpson, got %s (%v)", string(s), err)
+	}
+
+	meta.ParseDeprecated = goconstants.RejectDeprecated
+
+	if _, ok := meta.FromStringHelper("Maggie Simpson"); ok {
+		t.Errorf("rejected deprecated values should not be parsed")
+	}
+
+	_, err := meta.ParseHelper("Maggie Simpson")
+	var deprecatedErr *goconstants.DeprecatedValueError
+	if !errors.As(err, &deprecatedErr) {
+		t.Fatalf("expected a DeprecatedValueError, got %v", err)
+	}
+	if deprecatedErr.Message != "too young" || deprecatedErr.Value != simpson(maggie) {
+		t.Errorf("unexpected error content %#v", deprecatedErr)
+	}
+	if errors.Is(err, goconstants.ErrUnknownValue) {
+		t.Errorf("deprecated values should not be reported as unknown")
+	}
+
+	for _, src := range []any{int64(maggie), "5", []byte("5")} {
+		if err := meta.ScanHelper(src, &value); !errors.Is(err, goconstants.ErrDeprecatedValue) {
+			t.Errorf("expected error %v for %#v, got %v", goconstants.ErrDeprecatedValue, src, err)
+		}
+	}
+}
+
+func TestDeprecatedValueError(t *testing.T) {
+	err := &goconstants.DeprecatedValueError{Enum: "simpson", Value: maggie, Message: "too young"}
+	expected := "deprecated simpson value: 5 (too young)"
+	if err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err.Error())
+	}
+}
+
+func TestValidateDeprecations(t *testing.T) {
+	testCases := []struct {
+		name          string
+		deprecations  map[simpson]goconstants.Deprecation[simpson]
+		policy        goconstants.DeprecationPolicy
+		expectedError error
+	}{
+		{
+			name:          "unknown value",
+			deprecations:  map[simpson]goconstants.Deprecation[simpson]{999: {}},
+			expectedError: goconstants.ErrDeprecationUnknownValue,
+		},
+		{
+			name: "unknown replacement",
+			deprecations: map[simpson]goconstants.Deprecation[simpson]{
+				bart: {Replacement: 999, HasReplacement: true},
+			},
+			expectedError: goconstants.ErrInvalidReplacement,
+		},
+		{
+			name: "deprecated replacement",
+			deprecations: map[simpson]goconstants.Deprecation[simpson]{
+				bart:   {Replacement: maggie, HasReplacement: true},
+				maggie: {Replacement: lisa, HasReplacement: true},
+			},
+			expectedError: goconstants.ErrInvalidReplacement,
+		},
+		{
+			name:          "missing replacement",
+			deprecations:  map[simpson]goconstants.Deprecation[simpson]{bart: {}},
+			policy:        goconstants.ReplaceDeprecated,
+			expectedError: goconstants.ErrInvalidReplacement,
+		},
+		{
+			name:          "unknown policy",
+			policy:        42,
+			expectedError: goconstants.ErrUnknownPolicy,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			meta := cstMeta
+			meta.Deprecations = testCase.deprecations
+			meta.ParseDeprecated = testCase.policy
+
+			if err := meta.Validate(); !errors.Is(err, testCase.expectedError) {
+				t.Errorf("expected error %v, got %v", testCase.expectedError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,353 @@
+package goconstants
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"io"
+)
+
+// Descriptor is a read-only version of Metadata, built with New.
+// The strings are copied at construction, so later changes to the maps
+// given to New have no effect. A Descriptor is safe for concurrent use.
+//
+// Descriptor exposes the same helpers as Metadata, it can replace a
+// Metadata variable without changing the wrapping methods.
+type Descriptor[T comparable] struct {
+	meta Metadata[T]
+}
+
+// Option configures the Metadata built by New.
+type Option[T comparable] func(meta *Metadata[T])
+
+// WithStrings sets the strings of the constant values (see Metadata.Strings).
+func WithStrings[T comparable](strings map[T]string) Option[T] {
+	return func(meta *Metadata[T]) {
+		meta.Strings = strings
+	}
+}
+
+// WithJSONStrings sets the JSON representations of the constant values
+// (see Metadata.JSONStrings).
+func WithJSONStrings[T comparable](jsonStrings map[T]string) Option[T] {
+	return func(meta *Metadata[T]) {
+		meta.JSONStrings = jsonStrings
+	}
+}
+
+// WithDBStrings sets the database representations of the constant values
+// (see Metadata.DBStrings).
+func WithDBStrings[T comparable](dbStrings map[T]string) Option[T] {
+	return func(meta *Metadata[T]) {
+		meta.DBStrings = dbStrings
+	}
+}
+
+// WithText sets the representation used by text helpers
+// (see Metadata.Text).
+func WithText[T comparable](r Representation) Option[T] {
+	return func(meta *Metadata[T]) {
+		meta.Text = r
+	}
+}
+
+// WithOrder sets the order of the values (see Metadata.Order).
+func WithOrder[T comparable](values ...T) Option[T] {
+	return func(meta *Metadata[T]) {
+		meta.Order = values
+	}
+}
+
+// WithParsing sets how representations are matched when parsing
+// (see Metadata.Parsing).
+func WithParsing[T comparable](opts ParseOptions) Option[T] {
+	return func(meta *Metadata[T]) {
+		meta.Parsing = opts
+	}
+}
+
+// WithAliases sets other strings accepted when parsing strings
+// (see Metadata.Aliases).
+func WithAliases[T comparable](aliases map[T][]string) Option[T] {
+	return func(meta *Metadata[T]) {
+		meta.Aliases = aliases
+	}
+}
+
+// WithJSONAliases sets other strings accepted when parsing JSON
+// (see Metadata.JSONAliases).
+func WithJSONAliases[T comparable](aliases map[T][]string) Option[T] {
+	return func(meta *Metadata[T]) {
+		meta.JSONAliases = aliases
+	}
+}
+
+// WithOnAlias sets the function called each time an alias is parsed
+// (see Metadata.OnAlias). It must be safe for concurrent use.
+func WithOnAlias[T comparable](onAlias func(v T, alias string)) Option[T] {
+	return func(meta *Metadata[T]) {
+		meta.OnAlias = onAlias
+	}
+}
+
+// WithListAccepted sets the maximal number of values for which parse errors
+// list all accepted representations (see Metadata.ListAccepted).
+func WithListAccepted[T comparable](n int) Option[T] {
+	return func(meta *Metadata[T]) {
+		meta.ListAccepted = n
+	}
+}
+
+// WithDeprecations sets the deprecated values (see Metadata.Deprecations).
+func WithDeprecations[T comparable](deprecations map[T]Deprecation[T]) Option[T] {
+	return func(meta *Metadata[T]) {
+		meta.Deprecations = deprecations
+	}
+}
+
+// WithDeprecationPolicies sets what happens when a deprecated value is
+// marshalled or parsed (see Metadata.MarshalDeprecated and
+// Metadata.ParseDeprecated).
+func WithDeprecationPolicies[T comparable](marshal DeprecationPolicy, parse DeprecationPolicy) Option[T] {
+	return func(meta *Metadata[T]) {
+		meta.MarshalDeprecated = marshal
+		meta.ParseDeprecated = parse
+	}
+}
+
+// WithOnDeprecated sets the function called by the NotifyDeprecated policy
+// (see Metadata.OnDeprecated). It must be safe for concurrent use.
+func WithOnDeprecated[T comparable](onDeprecated func(v T, deprecation Deprecation[T])) Option[T] {
+	return func(meta *Metadata[T]) {
+		meta.OnDeprecated = onDeprecated
+	}
+}
+
+// WithProtoNumbers sets the Protocol Buffers numbers of the constant values
+// (see Metadata.ProtoNumbers).
+func WithProtoNumbers[T comparable](numbers map[T]int32) Option[T] {
+	return func(meta *Metadata[T]) {
+		meta.ProtoNumbers = numbers
+	}
+}
+
+// WithProtoNames sets the Protocol Buffers names of the constant values
+// (see Metadata.ProtoNames).
+func WithProtoNames[T comparable](names map[T]string) Option[T] {
+	return func(meta *Metadata[T]) {
+		meta.ProtoNames = names
+	}
+}
+
+// WithProtoPrefix sets the prefix of the Protocol Buffers names
+// (see Metadata.ProtoPrefix).
+func WithProtoPrefix[T comparable](prefix string) Option[T] {
+	return func(meta *Metadata[T]) {
+		meta.ProtoPrefix = prefix
+	}
+}
+
+// WithGraphQLStrings sets the GraphQL enum values of the constant values
+// (see Metadata.GraphQLStrings).
+func WithGraphQLStrings[T comparable](graphQLStrings map[T]string) Option[T] {
+	return func(meta *Metadata[T]) {
+		meta.GraphQLStrings = graphQLStrings
+	}
+}
+
+// WithFlags allows combinations of values (see Metadata.Flags).
+func WithFlags[T comparable]() Option[T] {
+	return func(meta *Metadata[T]) {
+		meta.Flags = true
+	}
+}
+
+// FromMetadata uses the content of an existing Metadata, except its Name
+// which is always the one given to New.
+// Options given after FromMetadata override its content.
+func FromMetadata[T comparable](source Metadata[T]) Option[T] {
+	return func(meta *Metadata[T]) {
+		name := meta.Name
+		*meta = source
+		meta.Name = name
+	}
+}
+
+// New builds a Descriptor from the given options. The strings are copied and
+// the resulting Metadata is validated and compiled. The returned error
+// contains all problems found (see Metadata.Diagnose).
+func New[T comparable](name string, opts ...Option[T]) (*Descriptor[T], error) {
+	meta := Metadata[T]{Name: name}
+	for _, opt := range opts {
+		opt(&meta)
+	}
+
+	meta = meta.clone()
+	if err := meta.Diagnose(); err != nil {
+		return nil, fmt.Errorf("invalid %s metadata: %w", name, err)
+	}
+
+	return &Descriptor[T]{meta: meta.Compile()}, nil
+}
+
+// MustNew is like New but panics if the Metadata is invalid.
+// It simplifies the initialization of package level variables.
+func MustNew[T comparable](name string, opts ...Option[T]) *Descriptor[T] {
+	descriptor, err := New(name, opts...)
+	if err != nil {
+		panic(err)
+	}
+
+	return descriptor
+}
+
+// Name returns the name of the constant type.
+func (d *Descriptor[T]) Name() string {
+	return d.meta.Name
+}
+
+// Metadata returns a copy of the underlying Metadata. Changing it has no
+// effect on the Descriptor.
+func (d *Descriptor[T]) Metadata() Metadata[T] {
+	return d.meta.clone().Compile()
+}
+
+// Info see Metadata.Info.
+func (d *Descriptor[T]) Info() Info {
+	return d.meta.Info()
+}
+
+// JSONSchemaHelper see Metadata.JSONSchemaHelper.
+func (d *Descriptor[T]) JSONSchemaHelper() JSONSchema {
+	return d.meta.JSONSchemaHelper()
+}
+
+// StringHelper see Metadata.StringHelper.
+func (d *Descriptor[T]) StringHelper(v T) string {
+	return d.meta.StringHelper(v)
+}
+
+// ToStringHelper see Metadata.ToStringHelper.
+func (d *Descriptor[T]) ToStringHelper(v T) (string, error) {
+	return d.meta.ToStringHelper(v)
+}
+
+// FromStringHelper see Metadata.FromStringHelper.
+func (d *Descriptor[T]) FromStringHelper(representation string) (T, bool) {
+	return d.meta.FromStringHelper(representation)
+}
+
+// ParseHelper see Metadata.ParseHelper.
+func (d *Descriptor[T]) ParseHelper(representation string) (T, error) {
+	return d.meta.ParseHelper(representation)
+}
+
+// IsValidHelper see Metadata.IsValidHelper.
+func (d *Descriptor[T]) IsValidHelper(v T) bool {
+	return d.meta.IsValidHelper(v)
+}
+
+// IsDeprecatedHelper see Metadata.IsDeprecatedHelper.
+func (d *Descriptor[T]) IsDeprecatedHelper(v T) bool {
+	return d.meta.IsDeprecatedHelper(v)
+}
+
+// DeprecationHelper see Metadata.DeprecationHelper.
+func (d *Descriptor[T]) DeprecationHelper(v T) (Deprecation[T], bool) {
+	return d.meta.DeprecationHelper(v)
+}
+
+// MarshalJSONHelper see Metadata.MarshalJSONHelper.
+func (d *Descriptor[T]) MarshalJSONHelper(v T) ([]byte, error) {
+	return d.meta.MarshalJSONHelper(v)
+}
+
+// UnmarshalJSONHelper see Metadata.UnmarshalJSONHelper.
+func (d *Descriptor[T]) UnmarshalJSONHelper(b []byte, v *T) error {
+	return d.meta.UnmarshalJSONHelper(b, v)
+}
+
+// MarshalTextHelper see Metadata.MarshalTextHelper.
+func (d *Descriptor[T]) MarshalTextHelper(v T) ([]byte, error) {
+	return d.meta.MarshalTextHelper(v)
+}
+
+// UnmarshalTextHelper see Metadata.UnmarshalTextHelper.
+func (d *Descriptor[T]) UnmarshalTextHelper(b []byte, v *T) error {
+	return d.meta.UnmarshalTextHelper(b, v)
+}
+
+// ValueHelper see Metadata.ValueHelper.
+func (d *Descriptor[T]) ValueHelper(v T) (driver.Value, error) {
+	return d.meta.ValueHelper(v)
+}
+
+// ScanHelper see Metadata.ScanHelper.
+func (d *Descriptor[T]) ScanHelper(src any, v *T) error {
+	return d.meta.ScanHelper(src, v)
+}
+
+// ProtoNumberHelper see Metadata.ProtoNumberHelper.
+func (d *Descriptor[T]) ProtoNumberHelper(v T) (int32, error) {
+	return d.meta.ProtoNumberHelper(v)
+}
+
+// FromProtoNumberHelper see Metadata.FromProtoNumberHelper.
+func (d *Descriptor[T]) FromProtoNumberHelper(n int32) (T, error) {
+	return d.meta.FromProtoNumberHelper(n)
+}
+
+// ProtoNameHelper see Metadata.ProtoNameHelper.
+func (d *Descriptor[T]) ProtoNameHelper(v T) (string, error) {
+	return d.meta.ProtoNameHelper(v)
+}
+
+// FromProtoNameHelper see Metadata.FromProtoNameHelper.
+func (d *Descriptor[T]) FromProtoNameHelper(name string) (T, error) {
+	return d.meta.FromProtoNameHelper(name)
+}
+
+// GraphQLNameHelper see Metadata.GraphQLNameHelper.
+func (d *Descriptor[T]) GraphQLNameHelper(v T) (string, error) {
+	return d.meta.GraphQLNameHelper(v)
+}
+
+// FromGraphQLNameHelper see Metadata.FromGraphQLNameHelper.
+func (d *Descriptor[T]) FromGraphQLNameHelper(name string) (T, error) {
+	return d.meta.FromGraphQLNameHelper(name)
+}
+
+// MarshalGQLHelper see Metadata.MarshalGQLHelper.
+func (d *Descriptor[T]) MarshalGQLHelper(v T, w io.Writer) {
+	d.meta.MarshalGQLHelper(v, w)
+}
+
+// UnmarshalGQLHelper see Metadata.UnmarshalGQLHelper.
+func (d *Descriptor[T]) UnmarshalGQLHelper(src any, v *T) error {
+	return d.meta.UnmarshalGQLHelper(src, v)
+}
+
+// NewSet returns a set bound to the Descriptor, containing the given values
+// (see NewEnumSet).
+func (d *Descriptor[T]) NewSet(values ...T) (EnumSet[T], error) {
+	return newEnumSet(&d.meta, values...)
+}
+
+// Values see Metadata.Values.
+func (d *Descriptor[T]) Values() []T {
+	return d.meta.Values()
+}
+
+// Len see Metadata.Len.
+func (d *Descriptor[T]) Len() int {
+	return d.meta.Len()
+}
+
+// Index see Metadata.Index.
+func (d *Descriptor[T]) Index(v T) (int, bool) {
+	return d.meta.Index(v)
+}
+
+// At see Metadata.At.
+func (d *Descriptor[T]) At(i int) T {
+	return d.meta.At(i)
+}
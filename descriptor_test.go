@@ -0,0 +1,115 @@
+package goconstants_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/samonzeweb/goconstants"
+)
+
+func TestNew(t *testing.T) {
+	_, err := goconstants.New[simpson]("")
+	if !errors.Is(err, goconstants.ErrNameMissing) {
+		t.Errorf("expected %v, got %v", goconstants.ErrNameMissing, err)
+	}
+
+	_, err = goconstants.New[simpson]("simpson")
+	if !errors.Is(err, goconstants.ErrNoStringsDefined) {
+		t.Errorf("expected %v, got %v", goconstants.ErrNoStringsDefined, err)
+	}
+
+	strings := map[simpson]string{
+		homer: "Homer Simpson",
+		marge: "Marge Simpson",
+	}
+	descriptor, err := goconstants.New("simpson", goconstants.WithStrings(strings))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if descriptor.Name() != "simpson" {
+		t.Errorf("expected simpson, got %s", descriptor.Name())
+	}
+
+	// Changing the source must not alter the descriptor.
+	strings[homer] = "Max Power"
+	strings[bart] = "Bart Simpson"
+	if s := descriptor.StringHelper(homer); s != "Homer Simpson" {
+		t.Errorf("expected Homer Simpson, got %s", s)
+	}
+	if descriptor.IsValidHelper(bart) {
+		t.Errorf("bart should not be valid")
+	}
+
+	// Changing the returned metadata must not alter the descriptor.
+	meta := descriptor.Metadata()
+	meta.Strings[homer] = "Max Power"
+	if s := descriptor.StringHelper(homer); s != "Homer Simpson" {
+		t.Errorf("expected Homer Simpson, got %s", s)
+	}
+}
+
+func TestNewFromMetadata(t *testing.T) {
+	descriptor, err := goconstants.New("simpson",
+		goconstants.FromMetadata(cstMeta),
+		goconstants.WithStrings(map[simpson]string{
+			homer:  "Homer",
+			marge:  "Marge",
+			bart:   "Bart",
+			lisa:   "Lisa",
+			maggie: "Maggie",
+		}),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if descriptor.Name() != "simpson" {
+		t.Errorf("expected simpson, got %s", descriptor.Name())
+	}
+
+	if s := descriptor.StringHelper(lisa); s != "Lisa" {
+		t.Errorf("expected Lisa, got %s", s)
+	}
+
+	b, err := descriptor.MarshalJSONHelper(lisa)
+	if err != nil || string(b) != `"lisa_simpson"` {
+		t.Errorf("expected \"lisa_simpson\", got %s (%v)", string(b), err)
+	}
+
+	var value simpson
+	err = descriptor.UnmarshalJSONHelper([]byte(`"bart_simpson"`), &value)
+	if err != nil || value != bart {
+		t.Errorf("expected %v, got %v (%v)", bart, value, err)
+	}
+}
+
+func TestMustNew(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MustNew should panic with invalid metadata")
+		}
+	}()
+
+	goconstants.MustNew[simpson]("simpson")
+}
+
+func TestDescriptorConcurrentUse(t *testing.T) {
+	descriptor := goconstants.MustNew("simpson", goconstants.FromMetadata(cstMeta))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				value, ok := descriptor.FromStringHelper("Lisa Simpson")
+				if !ok || value != lisa {
+					t.Errorf("expected %v, got %v", lisa, value)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
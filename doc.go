@@ -7,8 +7,12 @@
 // small methods wrapping call to the helpers. Just pick helpers
 // you need and ignore the others.
 //
+// To avoid writing the methods, bind a Descriptor to a non exported
+// constant type and use the Enum generic type, which implements them.
+//
 // The helpers are convenient but not the fastest. In a critical path
 // consider using way to do the job, like using switch/case instead of maps.
+// Compile the metadata to avoid scanning the strings when parsing values.
 //
 // See example for usages.
 package goconstants
@@ -0,0 +1,185 @@
+package goconstants
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Errors about bindings.
+var (
+	// ErrAlreadyBound is returned by Bind if a Descriptor is already bound to
+	// the constant type.
+	ErrAlreadyBound = errors.New("a descriptor is already bound to the type")
+	// ErrNotBound is returned by the methods of Enum if no Descriptor is
+	// bound to the constant type.
+	ErrNotBound = errors.New("no descriptor bound to the type")
+)
+
+// Bind binds a Descriptor to its constant type, allowing the use of
+// Enum[T]. A type can be bound only once, usually when initializing
+// package level variables.
+// The type is also registered (see Register), it returns an error wrapping
+// ErrAlreadyRegistered if its name is already used, or if its type is
+// registered with a Metadata.
+func Bind[T comparable](d *Descriptor[T]) error {
+	return register(&entry{info: d.meta.Info(), diagnose: d.meta.Diagnose, descriptor: d})
+}
+
+// MustBind is like Bind but panics if the type can't be bound, and returns
+// the Descriptor. It simplifies the initialization of package level
+// variables.
+func MustBind[T comparable](d *Descriptor[T]) *Descriptor[T] {
+	if err := Bind(d); err != nil {
+		panic(err)
+	}
+
+	return d
+}
+
+// Bound returns the Descriptor bound to the constant type T, and a boolean
+// indicating if there is one.
+func Bound[T comparable]() (*Descriptor[T], bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	e, ok := registry.byType[reflect.TypeFor[T]()]
+	if !ok || e.descriptor == nil {
+		return nil, false
+	}
+
+	return e.descriptor.(*Descriptor[T]), true
+}
+
+// Enum wraps a constant value, and implements the usual interfaces (Stringer,
+// JSON, text, database) using the Descriptor bound to T (see Bind), without
+// any wrapping method to write.
+//
+// Combined with a non exported constant type, it leads to a safe constant
+// type: values can't be created outside of the package, except the zero
+// value, as long as the package does not expose values of the constant type
+// itself (ie through an exported Descriptor or EnumSet).
+//
+//	type language int
+//
+//	const golang language = 1
+//
+//	type Language = goconstants.Enum[language]
+//
+//	var Go = goconstants.EnumOf(golang)
+//
+// The methods of an Enum whose type is not bound act as for an unknown
+// value, and return errors wrapping ErrNotBound.
+type Enum[T comparable] struct {
+	value T
+}
+
+// EnumOf returns the Enum wrapping the given constant value.
+func EnumOf[T comparable](v T) Enum[T] {
+	return Enum[T]{value: v}
+}
+
+// String returns a string representation of the constant.
+// It implements the fmt.Stringer interface.
+func (e Enum[T]) String() string {
+	d, ok := Bound[T]()
+	if !ok {
+		return ""
+	}
+
+	return d.StringHelper(e.value)
+}
+
+// ToString returns string representation of the constant and an error if the
+// value is unknown.
+func (e Enum[T]) ToString() (string, error) {
+	d, err := bound[T]()
+	if err != nil {
+		return "", err
+	}
+
+	return d.ToStringHelper(e.value)
+}
+
+// IsValid checks if the constant is valid (known).
+func (e Enum[T]) IsValid() bool {
+	d, ok := Bound[T]()
+	return ok && d.IsValidHelper(e.value)
+}
+
+// IsDeprecated checks if the constant is deprecated.
+func (e Enum[T]) IsDeprecated() bool {
+	d, ok := Bound[T]()
+	return ok && d.IsDeprecatedHelper(e.value)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e Enum[T]) MarshalJSON() ([]byte, error) {
+	d, err := bound[T]()
+	if err != nil {
+		return nil, err
+	}
+
+	return d.MarshalJSONHelper(e.value)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *Enum[T]) UnmarshalJSON(b []byte) error {
+	d, err := bound[T]()
+	if err != nil {
+		return err
+	}
+
+	return d.UnmarshalJSONHelper(b, &e.value)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (e Enum[T]) MarshalText() ([]byte, error) {
+	d, err := bound[T]()
+	if err != nil {
+		return nil, err
+	}
+
+	return d.MarshalTextHelper(e.value)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (e *Enum[T]) UnmarshalText(b []byte) error {
+	d, err := bound[T]()
+	if err != nil {
+		return err
+	}
+
+	return d.UnmarshalTextHelper(b, &e.value)
+}
+
+// Value implements driver.Valuer.
+func (e Enum[T]) Value() (driver.Value, error) {
+	d, err := bound[T]()
+	if err != nil {
+		return nil, err
+	}
+
+	return d.ValueHelper(e.value)
+}
+
+// Scan implements sql.Scanner.
+func (e *Enum[T]) Scan(src any) error {
+	d, err := bound[T]()
+	if err != nil {
+		return err
+	}
+
+	return d.ScanHelper(src, &e.value)
+}
+
+// bound returns the Descriptor bound to T, or an error wrapping ErrNotBound.
+func bound[T comparable]() (*Descriptor[T], error) {
+	d, ok := Bound[T]()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotBound, reflect.TypeFor[T]())
+	}
+
+	return d, nil
+}
@@ -0,0 +1,83 @@
+package goconstants_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/samonzeweb/goconstants"
+)
+
+// unbound is a constant type never bound to a descriptor.
+type unbound int
+
+func TestEnumRoundTrip(t *testing.T) {
+	// Planet as JSON map keys uses MarshalText
+	distances := map[Planet]float64{Mercury: 0.39, Earth: 1}
+	b, err := json.Marshal(distances)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	expected := `{"earth":1,"mercury":0.39}`
+	if string(b) != expected {
+		t.Errorf("expected %s, got %s", expected, string(b))
+	}
+
+	var decoded map[Planet]float64
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(decoded) != 2 || decoded[Mercury] != 0.39 || decoded[Earth] != 1 {
+		t.Errorf("expected %v, got %v", distances, decoded)
+	}
+
+	value, err := Venus.Value()
+	if err != nil || value != int64(2) {
+		t.Errorf("expected 2, got %v (%v)", value, err)
+	}
+
+	var scanned Planet
+	if err := scanned.Scan(int64(3)); err != nil || scanned != Earth {
+		t.Errorf("expected %v, got %v (%v)", Earth, scanned, err)
+	}
+
+	if err := scanned.UnmarshalJSON([]byte(`"pluto"`)); !errors.Is(err, goconstants.ErrUnknownValue) {
+		t.Errorf("expected error %v, got %v", goconstants.ErrUnknownValue, err)
+	}
+}
+
+func TestEnumNotBound(t *testing.T) {
+	e := goconstants.EnumOf(unbound(1))
+
+	if e.String() != "" || e.IsValid() || e.IsDeprecated() {
+		t.Errorf("an unbound enum should act as an unknown value")
+	}
+
+	if _, err := e.MarshalJSON(); !errors.Is(err, goconstants.ErrNotBound) {
+		t.Errorf("expected error %v, got %v", goconstants.ErrNotBound, err)
+	}
+
+	if err := e.Scan(int64(1)); !errors.Is(err, goconstants.ErrNotBound) {
+		t.Errorf("expected error %v, got %v", goconstants.ErrNotBound, err)
+	}
+
+	if _, ok := goconstants.Bound[unbound](); ok {
+		t.Errorf("unbound should not be bound")
+	}
+}
+
+func TestBindTwice(t *testing.T) {
+	d, ok := goconstants.Bound[planet]()
+	if !ok || d != planets {
+		t.Fatalf("expected the planets descriptor, got %v", d)
+	}
+
+	err := goconstants.Bind(goconstants.MustNew("Planet", goconstants.WithStrings(map[planet]string{1: "Mercury"})))
+	if !errors.Is(err, goconstants.ErrAlreadyBound) {
+		t.Errorf("expected error %v, got %v", goconstants.ErrAlreadyBound, err)
+	}
+
+	if d, _ := goconstants.Bound[planet](); d != planets {
+		t.Errorf("the first binding should be kept")
+	}
+}
@@ -0,0 +1,115 @@
+// Package enumgraphql exports goconstants types as GraphQL SDL enum
+// definitions, using the GraphQL names of the values (see
+// goconstants.Metadata.GraphQLStrings):
+//
+//	"GopherState values."
+//	enum GopherState {
+//	  "Zzz"
+//	  ASLEEP
+//	  "Lol"
+//	  JOKING @deprecated(reason: "not a state, use CODING")
+//	}
+//
+// The descriptions are the strings of the values, and deprecated values have
+// the @deprecated directive.
+package enumgraphql
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/samonzeweb/goconstants"
+	"github.com/samonzeweb/goconstants/internal/literal"
+	"github.com/samonzeweb/goconstants/internal/naming"
+)
+
+// Write writes the SDL enum definitions of the constant types.
+// It returns an error wrapping goconstants.ErrInvalidGraphQLName or
+// goconstants.ErrDuplicateGraphQLString if a name is invalid or duplicate,
+// as Validate does not check the names derived from the JSON strings.
+func Write(w io.Writer, infos ...goconstants.Info) error {
+	names := make([]string, len(infos))
+	for i, info := range infos {
+		if err := check(info); err != nil {
+			return err
+		}
+
+		name, err := TypeName(info)
+		if err != nil {
+			return err
+		}
+		names[i] = name
+	}
+
+	bw := bufio.NewWriter(w)
+	for i, info := range infos {
+		if i > 0 {
+			fmt.Fprintln(bw)
+		}
+		writeEnum(bw, info, names[i])
+	}
+
+	return bw.Flush()
+}
+
+// TypeName returns the name of the GraphQL enum of a constant type, its
+// name if it's a valid GraphQL name, or its name in PascalCase otherwise.
+// It returns an error wrapping goconstants.ErrInvalidGraphQLName if the name
+// can't be represented, ie if it has no letters or digits.
+func TypeName(info goconstants.Info) (string, error) {
+	if goconstants.IsGraphQLName(info.Name) {
+		return info.Name, nil
+	}
+
+	if name, ok := naming.Pascal(info.Name); ok {
+		return name, nil
+	}
+
+	return "", fmt.Errorf("%w: can't derive a type name from %q", goconstants.ErrInvalidGraphQLName, info.Name)
+}
+
+// writeEnum writes the definition of a constant type, named name.
+func writeEnum(w io.Writer, info goconstants.Info, name string) {
+	fmt.Fprintf(w, "%s\n", literal.Quote(info.Name+" values."))
+	fmt.Fprintf(w, "enum %s {\n", name)
+
+	for _, value := range info.Values {
+		if value.String != "" {
+			fmt.Fprintf(w, "  %s\n", literal.Quote(value.String))
+		}
+
+		if !value.Deprecated {
+			fmt.Fprintf(w, "  %s\n", value.GraphQLName)
+			continue
+		}
+
+		reason := info.DeprecationNote(value, func(replacement goconstants.ValueInfo) string {
+			return replacement.GraphQLName
+		})
+
+		if reason == "" {
+			fmt.Fprintf(w, "  %s @deprecated\n", value.GraphQLName)
+		} else {
+			fmt.Fprintf(w, "  %s @deprecated(reason: %s)\n", value.GraphQLName, literal.Quote(reason))
+		}
+	}
+
+	fmt.Fprintf(w, "}\n")
+}
+
+// check returns an error if a GraphQL name is invalid or duplicate.
+func check(info goconstants.Info) error {
+	used := make(map[string]bool, len(info.Values))
+	for _, value := range info.Values {
+		if !goconstants.IsGraphQLName(value.GraphQLName) {
+			return fmt.Errorf("%w: %q for %s", goconstants.ErrInvalidGraphQLName, value.GraphQLName, info.Name)
+		}
+		if used[value.GraphQLName] {
+			return fmt.Errorf("%w: %q is used by several values of %s", goconstants.ErrDuplicateGraphQLString, value.GraphQLName, info.Name)
+		}
+		used[value.GraphQLName] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package enumgraphql_test
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/samonzeweb/goconstants"
+	"github.com/samonzeweb/goconstants/enumgraphql"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+type gopherState int
+
+const (
+	asleep gopherState = iota + 1
+	coding
+	joking
+	eating
+)
+
+var gopherStateInfo = goconstants.Metadata[gopherState]{
+	Name:        "GopherState",
+	Strings:     map[gopherState]string{asleep: "Zzz", coding: `Coding "hard"`, joking: "Lol", eating: "Miam"},
+	JSONStrings: map[gopherState]string{asleep: "asleep", coding: "coding-hard", joking: "joking", eating: "eating"},
+	Deprecations: map[gopherState]goconstants.Deprecation[gopherState]{
+		joking: {Message: "not a state", Replacement: coding, HasReplacement: true},
+		eating: {},
+	},
+}.Info()
+
+var sizeInfo = goconstants.Metadata[int]{
+	Name:           "t-shirt size",
+	Strings:        map[int]string{1: "Small", 2: "Large"},
+	GraphQLStrings: map[int]string{1: "S", 2: "L"},
+}.Info()
+
+func TestWrite(t *testing.T) {
+	var buf bytes.Buffer
+	if err := enumgraphql.Write(&buf, gopherStateInfo, sizeInfo); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	golden := filepath.Join("testdata", "enums.golden.graphql")
+	if *update {
+		if err := os.WriteFile(golden, buf.Bytes(), 0o644); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+
+	expected, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if buf.String() != string(expected) {
+		t.Errorf("generated schema differs from %s:\n%s", golden, buf.String())
+	}
+}
+
+func TestTypeName(t *testing.T) {
+	testCases := []struct {
+		input         string
+		expected      string
+		expectedError error
+	}{
+		{input: "GopherState", expected: "GopherState"},
+		{input: "t-shirt size", expected: "TShirtSize"},
+		{input: "état", expected: "Etat"},
+		{input: "--", expectedError: goconstants.ErrInvalidGraphQLName},
+	}
+
+	for _, testCase := range testCases {
+		name, err := enumgraphql.TypeName(goconstants.Info{Name: testCase.input})
+		if !errors.Is(err, testCase.expectedError) {
+			t.Errorf("expected error %v, got %v", testCase.expectedError, err)
+		}
+		if name != testCase.expected {
+			t.Errorf("expected %s, got %s", testCase.expected, name)
+		}
+	}
+}
+
+func TestWriteInvalidNames(t *testing.T) {
+	testCases := []struct {
+		name          string
+		typeName      string
+		strings       map[int]string
+		expectedError error
+	}{
+		{name: "invalid", typeName: "Invalid", strings: map[int]string{1: "+"}, expectedError: goconstants.ErrInvalidGraphQLName},
+		{
+			name:          "duplicate",
+			typeName:      "Invalid",
+			strings:       map[int]string{1: "a-b", 2: "a_b"},
+			expectedError: goconstants.ErrDuplicateGraphQLString,
+		},
+		{name: "type name", typeName: "東京", strings: map[int]string{1: "tokyo"}, expectedError: goconstants.ErrInvalidGraphQLName},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			info := goconstants.Metadata[int]{Name: testCase.typeName, Strings: testCase.strings}.Info()
+
+			var buf bytes.Buffer
+			if err := enumgraphql.Write(&buf, info); !errors.Is(err, testCase.expectedError) {
+				t.Errorf("expected error %v, got %v", testCase.expectedError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+// Package enumhttp serves the description of goconstants types as JSON,
+// allowing frontends to use the same values and labels than the server.
+//
+// The response is an array of constant types sorted by name, each one
+// having its values in order:
+//
+//	[
+//	  {
+//	    "name": "GopherState",
+//	    "values": [
+//	      {"json": "asleep", "string": "Zzz", "deprecated": false},
+//	      {"json": "joking", "string": "Lol", "deprecated": true,
+//	       "deprecationMessage": "not a state", "replacement": "coding"}
+//	    ]
+//	  }
+//	]
+//
+// Flags (see goconstants.Metadata.Flags) have "flags": true, their JSON
+// representation being an array of the JSON strings of the values.
+//
+// The name query parameter, repeated or comma-separated, restricts the
+// response to some constant types. The ETag header is a hash of the content,
+// and conditional requests using If-None-Match are supported.
+package enumhttp
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/samonzeweb/goconstants"
+)
+
+// Handler is an http.Handler serving the description of constant types.
+type Handler struct {
+	infos func() []goconstants.Info
+}
+
+// New returns a Handler serving the registered constant types (see
+// goconstants.Register). The registry is read on each request.
+func New() *Handler {
+	return &Handler{infos: goconstants.Registered}
+}
+
+// NewFor returns a Handler serving the given constant types only.
+func NewFor(infos ...goconstants.Info) *Handler {
+	sorted := append([]goconstants.Info{}, infos...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	return &Handler{infos: func() []goconstants.Info { return sorted }}
+}
+
+// enumDescription is the JSON description of a constant type.
+type enumDescription struct {
+	Name   string             `json:"name"`
+	Flags  bool               `json:"flags,omitempty"`
+	Values []valueDescription `json:"values"`
+}
+
+// valueDescription is the JSON description of a value.
+type valueDescription struct {
+	JSON               string `json:"json"`
+	String             string `json:"string"`
+	Deprecated         bool   `json:"deprecated"`
+	DeprecationMessage string `json:"deprecationMessage,omitempty"`
+	Replacement        string `json:"replacement,omitempty"`
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	infos, err := filter(h.infos(), names(r))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	body, err := json.Marshal(describe(infos))
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	w.Header().Set("ETag", etag)
+
+	if matches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Length", fmt.Sprint(len(body)))
+	if r.Method == http.MethodHead {
+		return
+	}
+
+	_, _ = w.Write(body)
+}
+
+// names returns the names given in the name query parameters.
+func names(r *http.Request) []string {
+	var result []string
+	for _, value := range r.URL.Query()["name"] {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				result = append(result, name)
+			}
+		}
+	}
+
+	return result
+}
+
+// filter returns the constant types having the given names, or all of them
+// if there is no name. It returns an error if a name is unknown.
+func filter(infos []goconstants.Info, names []string) ([]goconstants.Info, error) {
+	if len(names) == 0 {
+		return infos, nil
+	}
+
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+
+	var result []goconstants.Info
+	for _, info := range infos {
+		if wanted[info.Name] {
+			result = append(result, info)
+			delete(wanted, info.Name)
+		}
+	}
+
+	if len(wanted) > 0 {
+		unknown := make([]string, 0, len(wanted))
+		for name := range wanted {
+			unknown = append(unknown, name)
+		}
+		sort.Strings(unknown)
+
+		return nil, fmt.Errorf("unknown constant types: %s", strings.Join(unknown, ", "))
+	}
+
+	return result, nil
+}
+
+// describe returns the JSON descriptions of the constant types.
+func describe(infos []goconstants.Info) []enumDescription {
+	descriptions := make([]enumDescription, 0, len(infos))
+	for _, info := range infos {
+		description := enumDescription{
+			Name:   info.Name,
+			Flags:  info.Flags,
+			Values: make([]valueDescription, 0, len(info.Values)),
+		}
+
+		for _, value := range info.Values {
+			description.Values = append(description.Values, valueDescription{
+				JSON:               value.JSONString,
+				String:             value.String,
+				Deprecated:         value.Deprecated,
+				DeprecationMessage: value.DeprecationMessage,
+				Replacement:        replacement(info, value),
+			})
+		}
+
+		descriptions = append(descriptions, description)
+	}
+
+	return descriptions
+}
+
+// replacement returns the JSON string of the replacement of a value, or a
+// blank string if there is none.
+func replacement(info goconstants.Info, value goconstants.ValueInfo) string {
+	if replacement, ok := info.ReplacementOf(value); ok {
+		return replacement.JSONString
+	}
+
+	return ""
+}
+
+// matches checks if the If-None-Match header matches the ETag.
+func matches(ifNoneMatch string, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,200 @@
+package enumhttp_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/samonzeweb/goconstants"
+	"github.com/samonzeweb/goconstants/enumhttp"
+)
+
+type color int
+
+const (
+	red color = iota + 1
+	green
+	blue
+)
+
+type size int
+
+const (
+	small size = iota + 1
+	large
+)
+
+func init() {
+	goconstants.MustRegister(goconstants.Metadata[color]{
+		Name:        "Color",
+		Strings:     map[color]string{red: "Red", green: "Green", blue: "Blue"},
+		JSONStrings: map[color]string{red: "red", green: "green", blue: "blue"},
+		Order:       []color{red, green, blue},
+		Deprecations: map[color]goconstants.Deprecation[color]{
+			blue: {Message: "too sad", Replacement: green, HasReplacement: true},
+		},
+	})
+	goconstants.MustRegister(goconstants.Metadata[size]{
+		Name:    "Size",
+		Strings: map[size]string{small: "S", large: "L"},
+	})
+}
+
+const (
+	colorJSON = `{"name":"Color","values":[` +
+		`{"json":"red","string":"Red","deprecated":false},` +
+		`{"json":"green","string":"Green","deprecated":false},` +
+		`{"json":"blue","string":"Blue","deprecated":true,"deprecationMessage":"too sad","replacement":"green"}]}`
+	sizeJSON = `{"name":"Size","values":[` +
+		`{"json":"S","string":"S","deprecated":false},` +
+		`{"json":"L","string":"L","deprecated":false}]}`
+)
+
+func TestHandler(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		url            string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "all",
+			method:         http.MethodGet,
+			url:            "/",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "[" + colorJSON + "," + sizeJSON + "]",
+		},
+		{
+			name:           "filter",
+			method:         http.MethodGet,
+			url:            "/?name=Size",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "[" + sizeJSON + "]",
+		},
+		{
+			name:           "several filters",
+			method:         http.MethodGet,
+			url:            "/?name=Size,Color",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "[" + colorJSON + "," + sizeJSON + "]",
+		},
+		{
+			name:           "head",
+			method:         http.MethodHead,
+			url:            "/",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "unknown name",
+			method:         http.MethodGet,
+			url:            "/?name=Size&name=Weight",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "unknown constant types: Weight\n",
+		},
+		{
+			name:           "unsupported method",
+			method:         http.MethodPost,
+			url:            "/",
+			expectedStatus: http.StatusMethodNotAllowed,
+			expectedBody:   "Method Not Allowed\n",
+		},
+	}
+
+	server := httptest.NewServer(enumhttp.New())
+	defer server.Close()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			request, err := http.NewRequest(testCase.method, server.URL+testCase.url, nil)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			response, err := server.Client().Do(request)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			defer response.Body.Close()
+
+			if response.StatusCode != testCase.expectedStatus {
+				t.Errorf("expected status %d, got %d", testCase.expectedStatus, response.StatusCode)
+			}
+
+			body, err := io.ReadAll(response.Body)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if string(body) != testCase.expectedBody {
+				t.Errorf("expected %s, got %s", testCase.expectedBody, string(body))
+			}
+		})
+	}
+}
+
+func TestHandlerETag(t *testing.T) {
+	handler := enumhttp.New()
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	etag := recorder.Header().Get("ETag")
+	if etag == "" {
+		t.Fatalf("the ETag header should be set")
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/?name=Size", nil))
+	if recorder.Header().Get("ETag") == etag {
+		t.Errorf("the ETag should depend on the content")
+	}
+
+	for _, ifNoneMatch := range []string{etag, `"other", ` + etag, "W/" + etag, "*"} {
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set("If-None-Match", ifNoneMatch)
+		recorder = httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		if recorder.Code != http.StatusNotModified || recorder.Body.Len() != 0 {
+			t.Errorf("expected status %d without body for %s, got %d", http.StatusNotModified, ifNoneMatch, recorder.Code)
+		}
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set("If-None-Match", `"other"`)
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, recorder.Code)
+	}
+}
+
+func TestNewFor(t *testing.T) {
+	info, _ := goconstants.Lookup("Size")
+	recorder := httptest.NewRecorder()
+	enumhttp.NewFor(info).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	expected := "[" + sizeJSON + "]"
+	if recorder.Body.String() != expected {
+		t.Errorf("expected %s, got %s", expected, recorder.Body.String())
+	}
+	if contentType := recorder.Header().Get("Content-Type"); contentType != "application/json" {
+		t.Errorf("expected application/json, got %s", contentType)
+	}
+}
+
+func TestFlags(t *testing.T) {
+	info := goconstants.Metadata[size]{
+		Name:    "Sizes",
+		Strings: map[size]string{small: "S", large: "L"},
+		Flags:   true,
+	}.Info()
+	recorder := httptest.NewRecorder()
+	enumhttp.NewFor(info).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	expected := `[{"name":"Sizes","flags":true,"values":[` +
+		`{"json":"S","string":"S","deprecated":false},` +
+		`{"json":"L","string":"L","deprecated":false}]}]`
+	if recorder.Body.String() != expected {
+		t.Errorf("expected %s, got %s", expected, recorder.Body.String())
+	}
+}
@@ -0,0 +1,392 @@
+// Package enumopenapi exports goconstants types as OpenAPI 3 schemas, to be
+// used as components/schemas entries.
+//
+// Each schema is a string schema listing the JSON strings of the values,
+// with the x-enum-varnames and x-enum-descriptions extensions used by client
+// generators to name the constants and document them:
+//
+//	GopherState:
+//	  title: GopherState
+//	  type: string
+//	  enum: [asleep, coding]
+//	  x-enum-varnames: [Asleep, Coding]
+//	  x-enum-descriptions: [Zzz, Coding]
+//
+// The variable names are derived from the JSON strings, in PascalCase. The
+// descriptions are the strings of the values, followed by the deprecation
+// notes of deprecated values.
+//
+// Flags are an array of JSON strings, as marshalled in JSON:
+//
+//	Permission:
+//	  title: Permission
+//	  type: array
+//	  items:
+//	    type: string
+//	    enum: [read, write]
+//	    ...
+//	  uniqueItems: true
+package enumopenapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/samonzeweb/goconstants"
+	"github.com/samonzeweb/goconstants/internal/naming"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is the format of an OpenAPI document.
+type Format int
+
+// Formats of OpenAPI documents.
+const (
+	JSON Format = iota
+	YAML
+)
+
+// Errors returned by the exporter.
+var (
+	// ErrUnknownFormat is returned when the format is unknown, or can't be
+	// deduced from a file name.
+	ErrUnknownFormat = errors.New("unknown OpenAPI format")
+	// ErrInvalidVarname is returned when a JSON string can't be represented
+	// as a variable name, ie if it has no letters or digits.
+	ErrInvalidVarname = errors.New("the JSON string can't be represented as a variable name")
+)
+
+// Schema is the OpenAPI schema of a constant type. The schema of flags (see
+// goconstants.Metadata.Flags) is an array whose items are the JSON strings
+// of the flags.
+type Schema struct {
+	Title            string   `json:"title,omitempty" yaml:"title,omitempty"`
+	Type             string   `json:"type" yaml:"type"`
+	Enum             []string `json:"enum,omitempty" yaml:"enum,omitempty"`
+	EnumVarnames     []string `json:"x-enum-varnames,omitempty" yaml:"x-enum-varnames,omitempty"`
+	EnumDescriptions []string `json:"x-enum-descriptions,omitempty" yaml:"x-enum-descriptions,omitempty"`
+	Items            *Schema  `json:"items,omitempty" yaml:"items,omitempty"`
+	UniqueItems      bool     `json:"uniqueItems,omitempty" yaml:"uniqueItems,omitempty"`
+}
+
+// NewSchema returns the OpenAPI schema of a constant type.
+// It returns an error wrapping ErrInvalidVarname if a JSON string can't be
+// represented as a variable name.
+func NewSchema(info goconstants.Info) (Schema, error) {
+	schema, err := stringsSchema(info)
+	if err != nil {
+		return Schema{}, err
+	}
+
+	if info.Flags {
+		return Schema{Title: info.Name, Type: "array", Items: &schema, UniqueItems: true}, nil
+	}
+
+	schema.Title = info.Name
+	return schema, nil
+}
+
+// stringsSchema returns the schema of the JSON strings of the values.
+func stringsSchema(info goconstants.Info) (Schema, error) {
+	schema := Schema{
+		Type:             "string",
+		Enum:             make([]string, 0, len(info.Values)),
+		EnumVarnames:     make([]string, 0, len(info.Values)),
+		EnumDescriptions: make([]string, 0, len(info.Values)),
+	}
+
+	used := make(map[string]bool, len(info.Values))
+	for _, value := range info.Values {
+		schema.Enum = append(schema.Enum, value.JSONString)
+		varname, ok := naming.Pascal(value.JSONString)
+		if !ok {
+			return Schema{}, fmt.Errorf("%w: %q for %s", ErrInvalidVarname, value.JSONString, info.Name)
+		}
+		schema.EnumVarnames = append(schema.EnumVarnames, unique(varname, used))
+		schema.EnumDescriptions = append(schema.EnumDescriptions, description(info, value))
+	}
+
+	return schema, nil
+}
+
+// Schemas returns the OpenAPI schemas of the constant types, keyed by name.
+func Schemas(infos ...goconstants.Info) (map[string]Schema, error) {
+	schemas := make(map[string]Schema, len(infos))
+	for _, info := range infos {
+		schema, err := NewSchema(info)
+		if err != nil {
+			return nil, err
+		}
+		schemas[info.Name] = schema
+	}
+
+	return schemas, nil
+}
+
+// Write writes an OpenAPI fragment containing the schemas of the constant
+// types in components/schemas.
+func Write(w io.Writer, format Format, infos ...goconstants.Info) error {
+	schemas, err := Schemas(infos...)
+	if err != nil {
+		return err
+	}
+
+	fragment := map[string]any{
+		"components": map[string]any{
+			"schemas": schemas,
+		},
+	}
+
+	switch format {
+	case JSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(fragment)
+	case YAML:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(fragment); err != nil {
+			return err
+		}
+		return encoder.Close()
+	default:
+		return fmt.Errorf("%w: %d", ErrUnknownFormat, format)
+	}
+}
+
+// Merge adds the schemas of the constant types to the components/schemas of
+// an existing OpenAPI document, replacing the schemas having the same names.
+// Other content is kept, YAML documents keep their order and comments.
+func Merge(spec []byte, format Format, infos ...goconstants.Info) ([]byte, error) {
+	switch format {
+	case JSON:
+		return mergeJSON(spec, infos)
+	case YAML:
+		return mergeYAML(spec, infos)
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownFormat, format)
+	}
+}
+
+// MergeFile is like Merge, but reads and writes the file at the given path.
+// The format is given by the file extension (.json, .yaml or .yml).
+func MergeFile(path string, infos ...goconstants.Info) error {
+	format, err := FormatOf(path)
+	if err != nil {
+		return err
+	}
+
+	spec, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	merged, err := Merge(spec, format, infos...)
+	if err != nil {
+		return fmt.Errorf("merging into %s: %w", path, err)
+	}
+
+	return os.WriteFile(path, merged, 0o644)
+}
+
+// FormatOf returns the format of a file, given by its extension.
+func FormatOf(path string) (Format, error) {
+	switch filepath.Ext(path) {
+	case ".json":
+		return JSON, nil
+	case ".yaml", ".yml":
+		return YAML, nil
+	default:
+		return 0, fmt.Errorf("%w for %s", ErrUnknownFormat, path)
+	}
+}
+
+// mergeJSON merges the schemas into a JSON document. The keys of the
+// resulting document are sorted.
+func mergeJSON(spec []byte, infos []goconstants.Info) ([]byte, error) {
+	merged, err := Schemas(infos...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Numbers are kept as written, as large integers would lose their precision
+	// as float64.
+	decoder := json.NewDecoder(bytes.NewReader(spec))
+	decoder.UseNumber()
+	var document map[string]any
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after the document")
+	}
+	if document == nil {
+		document = make(map[string]any)
+	}
+
+	components, err := jsonObject(document, "components")
+	if err != nil {
+		return nil, err
+	}
+	schemas, err := jsonObject(components, "schemas")
+	if err != nil {
+		return nil, err
+	}
+	for name, schema := range merged {
+		schemas[name] = schema
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// jsonObject returns the object having the given key in a JSON object,
+// creating it if needed.
+func jsonObject(parent map[string]any, key string) (map[string]any, error) {
+	value, ok := parent[key]
+	if !ok || value == nil {
+		object := make(map[string]any)
+		parent[key] = object
+		return object, nil
+	}
+
+	object, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%s is not an object", key)
+	}
+
+	return object, nil
+}
+
+// mergeYAML merges the schemas into a YAML document. New schemas are added
+// after the existing ones, sorted by name.
+func mergeYAML(spec []byte, infos []goconstants.Info) ([]byte, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(spec, &document); err != nil {
+		return nil, err
+	}
+	if document.Kind == 0 {
+		document = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	if document.Kind != yaml.DocumentNode || document.Content[0].Kind != yaml.MappingNode {
+		return nil, errors.New("the document is not an object")
+	}
+
+	components, err := yamlMapping(document.Content[0], "components")
+	if err != nil {
+		return nil, err
+	}
+	schemas, err := yamlMapping(components, "schemas")
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := append([]goconstants.Info{}, infos...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+	for _, info := range sorted {
+		schema, err := NewSchema(info)
+		if err != nil {
+			return nil, err
+		}
+
+		var node yaml.Node
+		if err := node.Encode(schema); err != nil {
+			return nil, err
+		}
+		setYAMLValue(schemas, info.Name, &node)
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&document); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// yamlMapping returns the mapping having the given key in a YAML mapping,
+// creating it if needed.
+func yamlMapping(parent *yaml.Node, key string) (*yaml.Node, error) {
+	if value := yamlValue(parent, key); value != nil && value.Tag != "!!null" {
+		if value.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("%s is not an object", key)
+		}
+		return value, nil
+	}
+
+	mapping := &yaml.Node{Kind: yaml.MappingNode}
+	setYAMLValue(parent, key, mapping)
+	return mapping, nil
+}
+
+// yamlValue returns the value having the given key in a YAML mapping, or nil.
+func yamlValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+// setYAMLValue sets the value having the given key in a YAML mapping,
+// replacing the existing one or adding it at the end.
+func setYAMLValue(mapping *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content[i+1] = value
+			return
+		}
+	}
+
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+}
+
+// description returns the description of a value, with its deprecation
+// note if it's deprecated.
+func description(info goconstants.Info, value goconstants.ValueInfo) string {
+	if !value.Deprecated {
+		return value.String
+	}
+
+	note := info.DeprecationNote(value, func(replacement goconstants.ValueInfo) string {
+		return replacement.JSONString
+	})
+	if note == "" {
+		return value.String + " (deprecated)"
+	}
+
+	return value.String + " (deprecated: " + note + ")"
+}
+
+// unique returns the name, suffixed by a number if it's already used.
+func unique(name string, used map[string]bool) string {
+	candidate := name
+	for i := 2; used[candidate]; i++ {
+		candidate = name + strconv.Itoa(i)
+	}
+	used[candidate] = true
+
+	return candidate
+}
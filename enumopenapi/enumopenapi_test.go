@@ -0,0 +1,313 @@
+package enumopenapi_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/samonzeweb/goconstants"
+	"github.com/samonzeweb/goconstants/enumopenapi"
+)
+
+type gopherState int
+
+const (
+	asleep gopherState = iota + 1
+	coding
+	joking
+)
+
+var gopherStateInfo = goconstants.Metadata[gopherState]{
+	Name:        "GopherState",
+	Strings:     map[gopherState]string{asleep: "Zzz", coding: "Coding", joking: "Lol"},
+	JSONStrings: map[gopherState]string{asleep: "asleep", coding: "coding-hard", joking: "joking"},
+	Deprecations: map[gopherState]goconstants.Deprecation[gopherState]{
+		joking: {Message: "not a state", Replacement: coding, HasReplacement: true},
+	},
+}.Info()
+
+const expectedYAML = `components:
+  schemas:
+    GopherState:
+      title: GopherState
+      type: string
+      enum:
+        - asleep
+        - coding-hard
+        - joking
+      x-enum-varnames:
+        - Asleep
+        - CodingHard
+        - Joking
+      x-enum-descriptions:
+        - Zzz
+        - Coding
+        - 'Lol (deprecated: not a state, use coding-hard)'
+`
+
+func TestNewSchema(t *testing.T) {
+	schema, err := enumopenapi.NewSchema(gopherStateInfo)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	expected := enumopenapi.Schema{
+		Title:            "GopherState",
+		Type:             "string",
+		Enum:             []string{"asleep", "coding-hard", "joking"},
+		EnumVarnames:     []string{"Asleep", "CodingHard", "Joking"},
+		EnumDescriptions: []string{"Zzz", "Coding", "Lol (deprecated: not a state, use coding-hard)"},
+	}
+	if !reflect.DeepEqual(schema, expected) {
+		t.Errorf("expected %v, got %v", expected, schema)
+	}
+}
+
+func TestNewSchemaUniqueVarnames(t *testing.T) {
+	info := goconstants.Metadata[int]{
+		Name:  "Separators",
+		Order: []int{1, 2, 3},
+		Strings: map[int]string{
+			1: "a-b",
+			2: "a_b",
+			3: "a b",
+		},
+	}.Info()
+
+	schema, err := enumopenapi.NewSchema(info)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	expected := []string{"AB", "AB2", "AB3"}
+	if !reflect.DeepEqual(schema.EnumVarnames, expected) {
+		t.Errorf("expected %v, got %v", expected, schema.EnumVarnames)
+	}
+}
+
+func TestNewSchemaFlags(t *testing.T) {
+	info := goconstants.Metadata[int]{
+		Name:        "Permission",
+		Strings:     map[int]string{1: "Read", 2: "Write"},
+		JSONStrings: map[int]string{1: "read", 2: "write"},
+		Flags:       true,
+	}.Info()
+
+	var buf bytes.Buffer
+	if err := enumopenapi.Write(&buf, enumopenapi.YAML, info); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	expected := `components:
+  schemas:
+    Permission:
+      title: Permission
+      type: array
+      items:
+        type: string
+        enum:
+          - read
+          - write
+        x-enum-varnames:
+          - Read
+          - Write
+        x-enum-descriptions:
+          - Read
+          - Write
+      uniqueItems: true
+`
+	if buf.String() != expected {
+		t.Errorf("expected %s, got %s", expected, buf.String())
+	}
+}
+
+func TestNewSchemaInvalidVarnames(t *testing.T) {
+	testCases := []struct {
+		name             string
+		strings          map[int]string
+		expectedVarnames []string
+		expectedError    error
+	}{
+		{name: "transliterated", strings: map[int]string{1: "crème brûlée"}, expectedVarnames: []string{"CremeBrulee"}},
+		{name: "no letters", strings: map[int]string{1: "+"}, expectedError: enumopenapi.ErrInvalidVarname},
+		{name: "not latin", strings: map[int]string{1: "東京"}, expectedError: enumopenapi.ErrInvalidVarname},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			info := goconstants.Metadata[int]{Name: "Invalid", Strings: testCase.strings}.Info()
+
+			schema, err := enumopenapi.NewSchema(info)
+			if !errors.Is(err, testCase.expectedError) {
+				t.Errorf("expected error %v, got %v", testCase.expectedError, err)
+			}
+			if err == nil && !reflect.DeepEqual(schema.EnumVarnames, testCase.expectedVarnames) {
+				t.Errorf("expected %v, got %v", testCase.expectedVarnames, schema.EnumVarnames)
+			}
+
+			var buf bytes.Buffer
+			if err := enumopenapi.Write(&buf, enumopenapi.YAML, info); !errors.Is(err, testCase.expectedError) {
+				t.Errorf("expected error %v, got %v", testCase.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	var buf bytes.Buffer
+	if err := enumopenapi.Write(&buf, enumopenapi.YAML, gopherStateInfo); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if buf.String() != expectedYAML {
+		t.Errorf("expected %s, got %s", expectedYAML, buf.String())
+	}
+
+	buf.Reset()
+	if err := enumopenapi.Write(&buf, enumopenapi.JSON, gopherStateInfo); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	var fragment struct {
+		Components struct {
+			Schemas map[string]enumopenapi.Schema `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &fragment); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	expected, err := enumopenapi.NewSchema(gopherStateInfo)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !reflect.DeepEqual(fragment.Components.Schemas["GopherState"], expected) {
+		t.Errorf("expected %v, got %s", expected, buf.String())
+	}
+
+	if err := enumopenapi.Write(&buf, 42, gopherStateInfo); !errors.Is(err, enumopenapi.ErrUnknownFormat) {
+		t.Errorf("expected error %v, got %v", enumopenapi.ErrUnknownFormat, err)
+	}
+}
+
+func TestMergeFile(t *testing.T) {
+	spec, err := os.ReadFile(filepath.Join("testdata", "spec.yaml"))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "spec.yaml")
+	if err := os.WriteFile(path, spec, 0o644); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if err := enumopenapi.MergeFile(path, gopherStateInfo); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	merged, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	// The existing content is kept, in order with its comments, and the
+	// existing GopherState schema is replaced.
+	for _, expected := range []string{
+		"openapi: 3.0.3\ninfo:\n",
+		"  # Schemas of the payloads.\n  schemas:\n    Gopher:\n",
+		"    GopherState:\n      title: GopherState\n",
+		"        - 'Lol (deprecated: not a state, use coding-hard)'\n",
+	} {
+		if !strings.Contains(string(merged), expected) {
+			t.Errorf("expected %q in %s", expected, string(merged))
+		}
+	}
+	if strings.Count(string(merged), "GopherState:") != 1 {
+		t.Errorf("the GopherState schema should be replaced, got %s", string(merged))
+	}
+}
+
+func TestMergeJSON(t *testing.T) {
+	testCases := []struct {
+		name          string
+		spec          string
+		expectedError bool
+	}{
+		{name: "no components", spec: `{"openapi":"3.1.0"}`},
+		{name: "no schemas", spec: `{"openapi":"3.1.0","components":{"responses":{}}}`},
+		{name: "existing schemas", spec: `{"openapi":"3.1.0","components":{"schemas":{"Gopher":{"type":"object"}}}}`},
+		{name: "invalid components", spec: `{"openapi":"3.1.0","components":[]}`, expectedError: true},
+		{name: "trailing data", spec: `{"openapi":"3.1.0"} {}`, expectedError: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			merged, err := enumopenapi.Merge([]byte(testCase.spec), enumopenapi.JSON, gopherStateInfo)
+			if testCase.expectedError {
+				if err == nil {
+					t.Errorf("expected an error, got %s", string(merged))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			var document struct {
+				OpenAPI    string `json:"openapi"`
+				Components struct {
+					Schemas map[string]json.RawMessage `json:"schemas"`
+				} `json:"components"`
+			}
+			if err := json.Unmarshal(merged, &document); err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if document.OpenAPI != "3.1.0" {
+				t.Errorf("expected 3.1.0, got %s", document.OpenAPI)
+			}
+			if _, ok := document.Components.Schemas["GopherState"]; !ok {
+				t.Errorf("GopherState is missing from %s", string(merged))
+			}
+			if strings.Contains(testCase.spec, "Gopher\"") && document.Components.Schemas["Gopher"] == nil {
+				t.Errorf("Gopher should be kept in %s", string(merged))
+			}
+		})
+	}
+}
+
+func TestMergeJSONNumbers(t *testing.T) {
+	spec := `{"openapi":"3.1.0","components":{"schemas":{"Id":{"type":"integer","maximum":9007199254740993,"minimum":0.1}}}}`
+
+	merged, err := enumopenapi.Merge([]byte(spec), enumopenapi.JSON, gopherStateInfo)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	for _, expected := range []string{`"maximum": 9007199254740993`, `"minimum": 0.1`} {
+		if !strings.Contains(string(merged), expected) {
+			t.Errorf("expected %s in %s", expected, string(merged))
+		}
+	}
+}
+
+func TestFormatOf(t *testing.T) {
+	testCases := []struct {
+		path          string
+		expected      enumopenapi.Format
+		expectedError error
+	}{
+		{path: "api.json", expected: enumopenapi.JSON},
+		{path: "api.yaml", expected: enumopenapi.YAML},
+		{path: "api.yml", expected: enumopenapi.YAML},
+		{path: "api.txt", expectedError: enumopenapi.ErrUnknownFormat},
+	}
+
+	for _, testCase := range testCases {
+		format, err := enumopenapi.FormatOf(testCase.path)
+		if !errors.Is(err, testCase.expectedError) || format != testCase.expected {
+			t.Errorf("expected %v (%v), got %v (%v)", testCase.expected, testCase.expectedError, format, err)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+// Package enumproto exports goconstants types as Protocol Buffers enum
+// definitions, using the numbers and names set in ProtoNumbers and
+// ProtoNames:
+//
+//	// GopherState values.
+//	enum GopherState {
+//	  GOPHER_STATE_UNSPECIFIED = 0;
+//	  // Zzz
+//	  GOPHER_STATE_ASLEEP = 1;
+//	}
+//
+// The value numbered 0 is always the first one, as required by proto3.
+// Deprecated values have the deprecated option.
+//
+// Converting values from and to Protocol Buffers is done with the helpers of
+// the goconstants package, which does not depend on any Protocol Buffers
+// runtime.
+package enumproto
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/samonzeweb/goconstants"
+	"github.com/samonzeweb/goconstants/internal/naming"
+)
+
+// identifierPattern matches the valid Protocol Buffers identifiers.
+var identifierPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// Write writes the enum definitions of the constant types, to be included in
+// a .proto file. It returns an error wrapping goconstants.ErrNoProtoNumbers
+// if a constant type has no Protocol Buffers numbers.
+func Write(w io.Writer, infos ...goconstants.Info) error {
+	names := make([]string, len(infos))
+	for i, info := range infos {
+		if !hasNumbers(info) {
+			return fmt.Errorf("%w for %s", goconstants.ErrNoProtoNumbers, info.Name)
+		}
+
+		name, err := EnumName(info)
+		if err != nil {
+			return err
+		}
+		names[i] = name
+	}
+
+	bw := bufio.NewWriter(w)
+	for i, info := range infos {
+		if i > 0 {
+			fmt.Fprintln(bw)
+		}
+		writeEnum(bw, info, names[i])
+	}
+
+	return bw.Flush()
+}
+
+// WriteFile writes a proto3 file in the given package, containing the enum
+// definitions of the constant types.
+func WriteFile(w io.Writer, pkg string, infos ...goconstants.Info) error {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "// Code generated by goconstants; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "syntax = \"proto3\";\n\n")
+	if pkg != "" {
+		fmt.Fprintf(&buf, "package %s;\n\n", pkg)
+	}
+
+	if err := Write(&buf, infos...); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, buf.String())
+	return err
+}
+
+// EnumName returns the name of the Protocol Buffers enum of a constant type,
+// its name if it's a valid identifier, or its name in PascalCase otherwise.
+// It returns an error wrapping goconstants.ErrInvalidProtoName if the name
+// can't be represented, ie if it starts with a digit.
+func EnumName(info goconstants.Info) (string, error) {
+	if identifierPattern.MatchString(info.Name) {
+		return info.Name, nil
+	}
+
+	if name, ok := naming.Pascal(info.Name); ok && identifierPattern.MatchString(name) {
+		return name, nil
+	}
+
+	return "", fmt.Errorf("%w: can't derive an enum name from %q", goconstants.ErrInvalidProtoName, info.Name)
+}
+
+// writeEnum writes the definition of a constant type, named name. The value
+// numbered 0 is written first.
+func writeEnum(w io.Writer, info goconstants.Info, name string) {
+	fmt.Fprintf(w, "// %s values.\n", info.Name)
+	fmt.Fprintf(w, "enum %s {\n", name)
+
+	if info.ProtoUnspecified != "" {
+		fmt.Fprintf(w, "  %s = 0;\n", info.ProtoUnspecified)
+	}
+	for _, value := range info.Values {
+		if value.ProtoNumber == 0 {
+			writeValue(w, info, value)
+		}
+	}
+	for _, value := range info.Values {
+		if value.ProtoNumber != 0 {
+			writeValue(w, info, value)
+		}
+	}
+
+	fmt.Fprintf(w, "}\n")
+}
+
+// writeValue writes a value of an enum, with its string as comment.
+func writeValue(w io.Writer, info goconstants.Info, value goconstants.ValueInfo) {
+	fmt.Fprintf(w, "  // %s\n", comment(value.String))
+
+	if !value.Deprecated {
+		fmt.Fprintf(w, "  %s = %d;\n", value.ProtoName, value.ProtoNumber)
+		return
+	}
+
+	note := info.DeprecationNote(value, func(replacement goconstants.ValueInfo) string {
+		return replacement.ProtoName
+	})
+	if note == "" {
+		fmt.Fprintf(w, "  // Deprecated\n")
+	} else {
+		fmt.Fprintf(w, "  // Deprecated: %s\n", comment(note))
+	}
+	fmt.Fprintf(w, "  %s = %d [deprecated = true];\n", value.ProtoName, value.ProtoNumber)
+}
+
+// hasNumbers checks if the values of a constant type have Protocol Buffers
+// numbers.
+func hasNumbers(info goconstants.Info) bool {
+	for _, value := range info.Values {
+		if value.ProtoName == "" {
+			return false
+		}
+	}
+
+	return len(info.Values) > 0
+}
+
+// comment returns a text usable in a single line comment.
+func comment(text string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(text)
+}
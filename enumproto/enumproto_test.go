@@ -0,0 +1,112 @@
+package enumproto_test
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/samonzeweb/goconstants"
+	"github.com/samonzeweb/goconstants/enumproto"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+type gopherState int
+
+const (
+	asleep gopherState = iota + 1
+	coding
+	joking
+)
+
+type priority int
+
+const (
+	low priority = iota
+	high
+)
+
+var gopherStateInfo = goconstants.Metadata[gopherState]{
+	Name:         "GopherState",
+	Strings:      map[gopherState]string{asleep: "Zzz", coding: "Coding", joking: "Lol"},
+	JSONStrings:  map[gopherState]string{asleep: "asleep", coding: "coding", joking: "joking"},
+	ProtoNumbers: map[gopherState]int32{asleep: 1, coding: 2, joking: 3},
+	Deprecations: map[gopherState]goconstants.Deprecation[gopherState]{
+		joking: {Message: "not a state", Replacement: coding, HasReplacement: true},
+	},
+}.Info()
+
+// priorityInfo has a known value numbered 0, declared last.
+var priorityInfo = goconstants.Metadata[priority]{
+	Name:         "priority",
+	Strings:      map[priority]string{low: "Low", high: "High"},
+	Order:        []priority{high, low},
+	ProtoNumbers: map[priority]int32{low: 0, high: 1},
+	ProtoNames:   map[priority]string{low: "PRIORITY_LOW", high: "PRIORITY_HIGH"},
+}.Info()
+
+func TestWriteFile(t *testing.T) {
+	var buf bytes.Buffer
+	if err := enumproto.WriteFile(&buf, "gophers.v1", gopherStateInfo, priorityInfo); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	golden := filepath.Join("testdata", "enums.golden.proto")
+	if *update {
+		if err := os.WriteFile(golden, buf.Bytes(), 0o644); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+
+	expected, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if buf.String() != string(expected) {
+		t.Errorf("generated code differs from %s:\n%s", golden, buf.String())
+	}
+}
+
+func TestWriteWithoutNumbers(t *testing.T) {
+	info := goconstants.Metadata[gopherState]{
+		Name:    "GopherState",
+		Strings: map[gopherState]string{asleep: "Zzz"},
+	}.Info()
+
+	var buf bytes.Buffer
+	if err := enumproto.Write(&buf, info); !errors.Is(err, goconstants.ErrNoProtoNumbers) {
+		t.Errorf("expected error %v, got %v", goconstants.ErrNoProtoNumbers, err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("nothing should be written, got %s", buf.String())
+	}
+}
+
+func TestEnumName(t *testing.T) {
+	testCases := []struct {
+		input         string
+		expected      string
+		expectedError error
+	}{
+		{input: "GopherState", expected: "GopherState"},
+		{input: "priority", expected: "priority"},
+		{input: "t-shirt size", expected: "TShirtSize"},
+		{input: "état du gopher", expected: "EtatDuGopher"},
+		{input: "2nd priority", expectedError: goconstants.ErrInvalidProtoName},
+		{input: "東京", expectedError: goconstants.ErrInvalidProtoName},
+	}
+
+	for _, testCase := range testCases {
+		name, err := enumproto.EnumName(goconstants.Info{Name: testCase.input})
+		if !errors.Is(err, testCase.expectedError) {
+			t.Errorf("expected error %v, got %v", testCase.expectedError, err)
+		}
+		if name != testCase.expected {
+			t.Errorf("expected %s, got %s", testCase.expected, name)
+		}
+	}
+}
@@ -0,0 +1,520 @@
+package goconstants
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"math/bits"
+	"strings"
+)
+
+// ErrMalformedArray is wrapped in the DecodeError returned by EnumSet.Scan
+// when the source is not a valid Postgres array literal.
+var ErrMalformedArray = errors.New("malformed array literal")
+
+// EnumSet is a set of known values of a constant type, bound to its
+// Metadata. The values are always iterated in the order of Values.
+//
+// The set is backed by a bitset for integer types whose values are dense,
+// and by a map otherwise. Like maps, copies of an EnumSet share their
+// content.
+//
+// The zero value is an empty set bound to the Descriptor bound to T (see
+// Bind) as soon as a value is added or decoded, allowing the use of EnumSet
+// in decoded structs.
+//
+// An EnumSet is marshalled to a JSON array of JSON strings, and stored in
+// databases as a Postgres array literal of the database representations (ie
+// {asleep,coding} or {1,3}).
+type EnumSet[T comparable] struct {
+	meta *Metadata[T]
+	// offset is the value of the first bit of words.
+	offset int64
+	// words contains the members for dense integer types, members is used
+	// otherwise.
+	words   []uint64
+	members map[T]struct{}
+}
+
+// NewEnumSet returns a set bound to the given Metadata, containing the given
+// values. The Metadata is compiled if it's not already the case, and must
+// not be modified later.
+// It returns an UnknownValueError if a value is not known.
+func NewEnumSet[T comparable](meta Metadata[T], values ...T) (EnumSet[T], error) {
+	if !meta.IsCompiled() {
+		meta = meta.Compile()
+	}
+
+	return newEnumSet(&meta, values...)
+}
+
+// MustNewEnumSet is like NewEnumSet but panics if a value is not known.
+// It simplifies the initialization of package level variables.
+func MustNewEnumSet[T comparable](meta Metadata[T], values ...T) EnumSet[T] {
+	set, err := NewEnumSet(meta, values...)
+	if err != nil {
+		panic(err)
+	}
+
+	return set
+}
+
+// newEnumSet returns a set bound to the given compiled Metadata.
+func newEnumSet[T comparable](meta *Metadata[T], values ...T) (EnumSet[T], error) {
+	set := emptySet(meta)
+	if err := set.Add(values...); err != nil {
+		return EnumSet[T]{}, err
+	}
+
+	return set, nil
+}
+
+// emptySet returns an empty set bound to the given Metadata, unbound if nil.
+func emptySet[T comparable](meta *Metadata[T]) EnumSet[T] {
+	set := EnumSet[T]{meta: meta}
+	if meta == nil {
+		return set
+	}
+
+	values := meta.getValues()
+	if low, span, ok := denseRange(values); ok {
+		set.offset = low
+		set.words = make([]uint64, span/64+1)
+		return set
+	}
+
+	set.members = make(map[T]struct{}, len(values))
+	return set
+}
+
+// denseRange returns the smallest value and the span of the values if they
+// are integers dense enough to be stored in a bitset.
+func denseRange[T comparable](values []T) (int64, uint64, bool) {
+	if len(values) == 0 {
+		return 0, 0, false
+	}
+
+	low, ok := toInt64(values[0])
+	if !ok {
+		return 0, 0, false
+	}
+	high := low
+	for _, v := range values[1:] {
+		n, ok := toInt64(v)
+		if !ok {
+			return 0, 0, false
+		}
+		low = min(low, n)
+		high = max(high, n)
+	}
+
+	span := uint64(high) - uint64(low)
+	if span >= 64 && span >= 2*uint64(len(values)) {
+		return 0, 0, false
+	}
+
+	return low, span, true
+}
+
+// ready binds a zero EnumSet to the Descriptor bound to T, or returns an
+// error wrapping ErrNotBound.
+func (s *EnumSet[T]) ready() error {
+	if s.meta != nil {
+		return nil
+	}
+
+	d, err := bound[T]()
+	if err != nil {
+		return err
+	}
+
+	*s = emptySet(&d.meta)
+	return nil
+}
+
+// Add adds values to the set.
+// It returns an UnknownValueError if a value is not known, and an error
+// wrapping ErrNotBound if the set is unbound.
+func (s *EnumSet[T]) Add(values ...T) error {
+	if err := s.ready(); err != nil {
+		return err
+	}
+
+	for _, v := range values {
+		if _, ok := s.meta.Index(v); !ok {
+			return &UnknownValueError{Enum: s.meta.Name, Value: v}
+		}
+	}
+
+	for _, v := range values {
+		s.add(v)
+	}
+
+	return nil
+}
+
+// Remove removes values from the set, unknown values are ignored.
+func (s *EnumSet[T]) Remove(values ...T) {
+	for _, v := range values {
+		if !s.Has(v) {
+			continue
+		}
+
+		if s.words != nil {
+			i := s.bit(v)
+			s.words[i/64] &^= 1 << (i % 64)
+		} else {
+			delete(s.members, v)
+		}
+	}
+}
+
+// Has checks if a value is a member of the set.
+func (s EnumSet[T]) Has(v T) bool {
+	if s.meta == nil {
+		return false
+	}
+
+	if _, ok := s.meta.Index(v); !ok {
+		return false
+	}
+
+	if s.words != nil {
+		i := s.bit(v)
+		return s.words[i/64]&(1<<(i%64)) != 0
+	}
+
+	_, ok := s.members[v]
+	return ok
+}
+
+// Len returns the number of members.
+func (s EnumSet[T]) Len() int {
+	if s.words == nil {
+		return len(s.members)
+	}
+
+	n := 0
+	for _, word := range s.words {
+		n += bits.OnesCount64(word)
+	}
+
+	return n
+}
+
+// All returns an iterator over the members, in the order of Values.
+func (s EnumSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if s.meta == nil {
+			return
+		}
+
+		for _, v := range s.meta.getValues() {
+			if s.Has(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns the members, in the order of Values.
+func (s EnumSet[T]) Values() []T {
+	values := make([]T, 0, s.Len())
+	for v := range s.All() {
+		values = append(values, v)
+	}
+
+	return values
+}
+
+// String returns the strings of the members, ie {Zzz, Lol}.
+// It implements the fmt.Stringer interface.
+func (s EnumSet[T]) String() string {
+	members := make([]string, 0, s.Len())
+	for v := range s.All() {
+		members = append(members, s.meta.StringHelper(v))
+	}
+
+	return "{" + strings.Join(members, ", ") + "}"
+}
+
+// Union returns a new set containing the members of both sets.
+func (s EnumSet[T]) Union(other EnumSet[T]) EnumSet[T] {
+	return s.combine(other,
+		func(a, b uint64) uint64 { return a | b },
+		func(a, b bool) bool { return a || b })
+}
+
+// Intersect returns a new set containing the members present in both sets.
+func (s EnumSet[T]) Intersect(other EnumSet[T]) EnumSet[T] {
+	return s.combine(other,
+		func(a, b uint64) uint64 { return a & b },
+		func(a, b bool) bool { return a && b })
+}
+
+// Difference returns a new set containing the members of s which are not
+// members of other.
+func (s EnumSet[T]) Difference(other EnumSet[T]) EnumSet[T] {
+	return s.combine(other,
+		func(a, b uint64) uint64 { return a &^ b },
+		func(a, b bool) bool { return a && !b })
+}
+
+// Complement returns a new set containing the known values which are not
+// members of s.
+func (s EnumSet[T]) Complement() EnumSet[T] {
+	result := s.empty(s)
+	if result.meta == nil {
+		return result
+	}
+
+	for _, v := range result.meta.getValues() {
+		if !s.Has(v) {
+			result.add(v)
+		}
+	}
+
+	return result
+}
+
+// combine returns a new set containing the known values kept according to
+// their presence in both sets. The bitsets are combined word by word when
+// possible.
+func (s EnumSet[T]) combine(other EnumSet[T], words func(a, b uint64) uint64, keep func(a, b bool) bool) EnumSet[T] {
+	result := s.empty(other)
+	if result.meta == nil {
+		return result
+	}
+
+	if result.sameLayout(s) && result.sameLayout(other) {
+		for i := range result.words {
+			result.words[i] = words(s.words[i], other.words[i])
+		}
+		return result
+	}
+
+	for _, v := range result.meta.getValues() {
+		if keep(s.Has(v), other.Has(v)) {
+			result.add(v)
+		}
+	}
+
+	return result
+}
+
+// empty returns an empty set bound to the Metadata of s, of other, or to the
+// Descriptor bound to T, in this order.
+func (s EnumSet[T]) empty(other EnumSet[T]) EnumSet[T] {
+	switch {
+	case s.meta != nil:
+		return emptySet(s.meta)
+	case other.meta != nil:
+		return emptySet(other.meta)
+	}
+
+	var result EnumSet[T]
+	_ = result.ready()
+	return result
+}
+
+// sameLayout checks if both sets are bitsets storing the same values at the
+// same positions.
+func (s EnumSet[T]) sameLayout(other EnumSet[T]) bool {
+	return s.words != nil && other.words != nil &&
+		s.offset == other.offset && len(s.words) == len(other.words)
+}
+
+// add adds a known value.
+func (s *EnumSet[T]) add(v T) {
+	if s.words != nil {
+		i := s.bit(v)
+		s.words[i/64] |= 1 << (i % 64)
+		return
+	}
+
+	s.members[v] = struct{}{}
+}
+
+// bit returns the position of a known value in the bitset.
+func (s EnumSet[T]) bit(v T) uint64 {
+	n, _ := toInt64(v)
+	return uint64(n) - uint64(s.offset)
+}
+
+// MarshalJSON implements json.Marshaler. The set is marshalled to a JSON
+// array of the JSON strings of its members (see Metadata.MarshalJSONHelper).
+func (s EnumSet[T]) MarshalJSON() ([]byte, error) {
+	members := make([]json.RawMessage, 0, s.Len())
+	for v := range s.All() {
+		b, err := s.meta.MarshalJSONHelper(v)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, b)
+	}
+
+	return json.Marshal(members)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The content must be a JSON
+// array of JSON strings (see Metadata.UnmarshalJSONHelper).
+// It returns a DecodeError if the content is not a JSON array, and an
+// UnknownValueError if a member is unknown. The set is unchanged on errors.
+func (s *EnumSet[T]) UnmarshalJSON(b []byte) error {
+	if err := s.ready(); err != nil {
+		return err
+	}
+
+	var members []json.RawMessage
+	if err := json.Unmarshal(b, &members); err != nil {
+		return &DecodeError{Enum: s.meta.Name, Format: "json", Err: err}
+	}
+
+	values := make([]T, len(members))
+	for i, member := range members {
+		if err := s.meta.UnmarshalJSONHelper(member, &values[i]); err != nil {
+			return err
+		}
+	}
+
+	return s.replace(values)
+}
+
+// Value implements driver.Valuer. The set is stored as a Postgres array
+// literal of the database representations of its members (see
+// Metadata.ValueHelper).
+func (s EnumSet[T]) Value() (driver.Value, error) {
+	members := make([]string, 0, s.Len())
+	for v := range s.All() {
+		value, err := s.meta.ValueHelper(v)
+		if err != nil {
+			return nil, err
+		}
+
+		if representation, ok := value.(string); ok {
+			members = append(members, quoteArrayElement(representation))
+		} else {
+			members = append(members, fmt.Sprint(value))
+		}
+	}
+
+	return "{" + strings.Join(members, ",") + "}", nil
+}
+
+// Scan implements sql.Scanner. The source must be a Postgres array literal,
+// as a string or a []byte, or nil for an empty set.
+// It returns a DecodeError if the source can't be converted, and an
+// UnknownValueError if a member is unknown. The set is unchanged on errors.
+func (s *EnumSet[T]) Scan(src any) error {
+	if err := s.ready(); err != nil {
+		return err
+	}
+
+	var literal string
+	switch src := src.(type) {
+	case nil:
+		return s.replace(nil)
+	case string:
+		literal = src
+	case []byte:
+		literal = string(src)
+	default:
+		return &DecodeError{
+			Enum:   s.meta.Name,
+			Format: dbFormat,
+			Err:    fmt.Errorf("unsupported source type %T", src),
+		}
+	}
+
+	elements, err := parseArrayLiteral(literal)
+	if err != nil {
+		return &DecodeError{Enum: s.meta.Name, Format: dbFormat, Err: err}
+	}
+
+	values := make([]T, len(elements))
+	for i, element := range elements {
+		if err := s.meta.ScanHelper(element, &values[i]); err != nil {
+			return err
+		}
+	}
+
+	return s.replace(values)
+}
+
+// replace replaces the members of the set.
+func (s *EnumSet[T]) replace(values []T) error {
+	set, err := newEnumSet(s.meta, values...)
+	if err != nil {
+		return err
+	}
+
+	*s = set
+	return nil
+}
+
+// quoteArrayElement quotes an element of a Postgres array literal if needed.
+func quoteArrayElement(element string) string {
+	if element != "" && !strings.EqualFold(element, "NULL") && !strings.ContainsAny(element, "{},\"\\ \t\n\r\v\f") {
+		return element
+	}
+
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return `"` + replacer.Replace(element) + `"`
+}
+
+// parseArrayLiteral returns the elements of a one-dimensional Postgres array
+// literal, ie {a,"b c"}. NULL elements are not allowed.
+func parseArrayLiteral(literal string) ([]string, error) {
+	if len(literal) < 2 || literal[0] != '{' || literal[len(literal)-1] != '}' {
+		return nil, fmt.Errorf("%w: %q", ErrMalformedArray, literal)
+	}
+
+	content := literal[1 : len(literal)-1]
+	if strings.TrimSpace(content) == "" {
+		return nil, nil
+	}
+
+	var elements []string
+	for i := 0; ; {
+		var element string
+		if i < len(content) && content[i] == '"' {
+			var b strings.Builder
+			for i++; i < len(content) && content[i] != '"'; i++ {
+				if content[i] == '\\' && i+1 < len(content) {
+					i++
+				}
+				b.WriteByte(content[i])
+			}
+			if i == len(content) {
+				return nil, fmt.Errorf("%w: unterminated element in %q", ErrMalformedArray, literal)
+			}
+			i++
+			element = b.String()
+		} else {
+			end := strings.IndexByte(content[i:], ',')
+			if end < 0 {
+				end = len(content) - i
+			}
+			element = strings.TrimSpace(content[i : i+end])
+			i += end
+
+			switch {
+			case strings.ContainsAny(element, `{}"`):
+				return nil, fmt.Errorf("%w: unexpected element %q in %q", ErrMalformedArray, element, literal)
+			case element == "" || strings.EqualFold(element, "NULL"):
+				return nil, fmt.Errorf("%w: empty or NULL element in %q", ErrMalformedArray, literal)
+			}
+		}
+
+		elements = append(elements, element)
+		if i == len(content) {
+			return elements, nil
+		}
+		if content[i] != ',' {
+			return nil, fmt.Errorf("%w: expected a comma in %q", ErrMalformedArray, literal)
+		}
+		i++
+	}
+}
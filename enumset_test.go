@@ -0,0 +1,267 @@
+package goconstants_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/samonzeweb/goconstants"
+)
+
+// code is a constant type with sparse values, stored in a map by EnumSet.
+type code int
+
+var codeMeta = goconstants.Metadata[code]{
+	Name:    "code",
+	Strings: map[code]string{7: "seven", 404: "not found", 100000: "big", -3: "negative"},
+	Order:   []code{404, 7, 100000, -3},
+}
+
+func TestEnumSetOperations(t *testing.T) {
+	t.Run("bitset", func(t *testing.T) {
+		checkSetOperations(t, cstMeta, homer, marge, bart, lisa, 42)
+	})
+	t.Run("map", func(t *testing.T) {
+		checkSetOperations(t, codeMeta, 404, 7, 100000, -3, 0)
+	})
+}
+
+// checkSetOperations checks the operations of sets of the given Metadata,
+// with a, b, c and d known values in the declared order, and unknown an
+// unknown value.
+func checkSetOperations[T comparable](t *testing.T, meta goconstants.Metadata[T], a, b, c, d, unknown T) {
+	s := goconstants.MustNewEnumSet(meta, c, a)
+	complement := slices.DeleteFunc(meta.Values(), func(v T) bool { return v == a || v == c })
+	other := goconstants.MustNewEnumSet(meta, c, d)
+
+	testCases := []struct {
+		name     string
+		set      goconstants.EnumSet[T]
+		expected []T
+	}{
+		{name: "set", set: s, expected: []T{a, c}},
+		{name: "union", set: s.Union(other), expected: []T{a, c, d}},
+		{name: "intersect", set: s.Intersect(other), expected: []T{c}},
+		{name: "difference", set: s.Difference(other), expected: []T{a}},
+		{name: "complement", set: s.Complement(), expected: complement},
+		{name: "empty complement", set: goconstants.MustNewEnumSet(meta).Complement(), expected: meta.Values()},
+	}
+
+	for _, testCase := range testCases {
+		if values := testCase.set.Values(); !slices.Equal(values, testCase.expected) {
+			t.Errorf("%s: expected %v, got %v", testCase.name, testCase.expected, values)
+		}
+		if testCase.set.Len() != len(testCase.expected) {
+			t.Errorf("%s: expected %d members, got %d", testCase.name, len(testCase.expected), testCase.set.Len())
+		}
+	}
+
+	// Operations return new sets.
+	if values := s.Values(); !slices.Equal(values, []T{a, c}) {
+		t.Errorf("expected %v, got %v", []T{a, c}, values)
+	}
+
+	if !s.Has(a) || s.Has(b) || s.Has(unknown) {
+		t.Errorf("unexpected members %v", s.Values())
+	}
+
+	if err := s.Add(b); err != nil || !s.Has(b) {
+		t.Errorf("expected %v to be added (%v)", b, err)
+	}
+	s.Remove(a, unknown)
+	if values := s.Values(); !slices.Equal(values, []T{b, c}) {
+		t.Errorf("expected %v, got %v", []T{b, c}, values)
+	}
+
+	if err := s.Add(d, unknown); !errors.Is(err, goconstants.ErrUnknownValue) {
+		t.Errorf("expected error %v, got %v", goconstants.ErrUnknownValue, err)
+	}
+	if s.Has(d) {
+		t.Errorf("no value should be added if one is unknown")
+	}
+}
+
+func TestEnumSetOrder(t *testing.T) {
+	meta := cstMeta
+	meta.Order = []simpson{maggie, lisa, bart, marge, homer}
+
+	s := goconstants.MustNewEnumSet(meta, homer, lisa, maggie)
+
+	var values []simpson
+	for v := range s.All() {
+		values = append(values, v)
+		if len(values) == 2 {
+			break
+		}
+	}
+
+	expected := []simpson{maggie, lisa}
+	if !slices.Equal(values, expected) {
+		t.Errorf("expected %v, got %v", expected, values)
+	}
+
+	if s.String() != "{Maggie Simpson, Lisa Simpson, Homer Simpson}" {
+		t.Errorf("unexpected string %s", s.String())
+	}
+}
+
+func TestNewEnumSetUnknownValue(t *testing.T) {
+	if _, err := goconstants.NewEnumSet(cstMeta, homer, 42); !errors.Is(err, goconstants.ErrUnknownValue) {
+		t.Errorf("expected error %v, got %v", goconstants.ErrUnknownValue, err)
+	}
+}
+
+func TestEnumSetJSON(t *testing.T) {
+	s := goconstants.MustNewEnumSet(cstMeta, lisa, homer)
+
+	b, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	expected := `["homer_simpson","lisa_simpson"]`
+	if string(b) != expected {
+		t.Errorf("expected %s, got %s", expected, string(b))
+	}
+
+	decoded := goconstants.MustNewEnumSet(cstMeta, bart)
+	if err := json.Unmarshal([]byte(`["maggie_simpson","homer_simpson"]`), &decoded); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if values := decoded.Values(); !slices.Equal(values, []simpson{homer, maggie}) {
+		t.Errorf("expected %v, got %v", []simpson{homer, maggie}, values)
+	}
+
+	testCases := []struct {
+		name  string
+		input string
+	}{
+		{name: "unknown member", input: `["homer_simpson","ned_flanders"]`},
+		{name: "not an array", input: `"homer_simpson"`},
+		{name: "not strings", input: `[1]`},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if err := json.Unmarshal([]byte(testCase.input), &decoded); err == nil {
+				t.Errorf("expected an error")
+			}
+			if values := decoded.Values(); !slices.Equal(values, []simpson{homer, maggie}) {
+				t.Errorf("the set should be unchanged on errors, got %v", values)
+			}
+		})
+	}
+}
+
+func TestEnumSetBound(t *testing.T) {
+	var travel struct {
+		Planets goconstants.EnumSet[planet] `json:"planets"`
+	}
+
+	if err := json.Unmarshal([]byte(`{"planets":["earth","mercury"]}`), &travel); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !travel.Planets.Has(earth) || travel.Planets.Has(venus) {
+		t.Errorf("unexpected members %v", travel.Planets)
+	}
+
+	complement := travel.Planets.Complement()
+	if values := complement.Values(); !slices.Equal(values, []planet{venus}) {
+		t.Errorf("expected %v, got %v", []planet{venus}, values)
+	}
+
+	s, err := planets.NewSet(venus)
+	if err != nil || s.String() != "{Venus}" {
+		t.Errorf("expected {Venus}, got %v (%v)", s, err)
+	}
+
+	var empty goconstants.EnumSet[unbound]
+	if err := empty.Add(1); !errors.Is(err, goconstants.ErrNotBound) {
+		t.Errorf("expected error %v, got %v", goconstants.ErrNotBound, err)
+	}
+	if empty.Has(1) || empty.Len() != 0 || empty.Complement().Len() != 0 {
+		t.Errorf("unbound sets should be empty")
+	}
+}
+
+func TestEnumSetSQL(t *testing.T) {
+	withDBStrings := cstMeta
+	withDBStrings.DBStrings = map[simpson]string{
+		homer:  "homer",
+		marge:  "marge",
+		bart:   `"el barto"`,
+		lisa:   "lisa",
+		maggie: "null",
+	}
+
+	testCases := []struct {
+		name     string
+		meta     goconstants.Metadata[simpson]
+		values   []simpson
+		expected driver.Value
+	}{
+		{name: "empty", meta: cstMeta, expected: "{}"},
+		{name: "integers", meta: cstMeta, values: []simpson{lisa, homer}, expected: "{1,4}"},
+		{name: "strings", meta: withDBStrings, values: []simpson{homer, lisa}, expected: "{homer,lisa}"},
+		{name: "quoted", meta: withDBStrings, values: []simpson{bart, maggie}, expected: `{"\"el barto\"","null"}`},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			s := goconstants.MustNewEnumSet(testCase.meta, testCase.values...)
+			value, err := s.Value()
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if value != testCase.expected {
+				t.Errorf("expected %v, got %v", testCase.expected, value)
+			}
+
+			scanned := goconstants.MustNewEnumSet(testCase.meta)
+			if err := scanned.Scan([]byte(value.(string))); err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if !slices.Equal(scanned.Values(), s.Values()) {
+				t.Errorf("expected %v, got %v", s.Values(), scanned.Values())
+			}
+		})
+	}
+}
+
+func TestEnumSetScanErrors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		src           any
+		expectedError error
+	}{
+		{name: "unknown member", src: "{1,42}", expectedError: goconstants.ErrUnknownValue},
+		{name: "not an array", src: "1,2", expectedError: goconstants.ErrMalformedArray},
+		{name: "null member", src: "{1,NULL}", expectedError: goconstants.ErrMalformedArray},
+		{name: "unterminated", src: `{"1}`, expectedError: goconstants.ErrMalformedArray},
+		{name: "nested", src: "{{1},{2}}", expectedError: goconstants.ErrMalformedArray},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			s := goconstants.MustNewEnumSet(cstMeta, bart)
+			if err := s.Scan(testCase.src); !errors.Is(err, testCase.expectedError) {
+				t.Errorf("expected error %v, got %v", testCase.expectedError, err)
+			}
+			if values := s.Values(); !slices.Equal(values, []simpson{bart}) {
+				t.Errorf("the set should be unchanged on errors, got %v", values)
+			}
+		})
+	}
+
+	var decodeErr *goconstants.DecodeError
+	s := goconstants.MustNewEnumSet(cstMeta)
+	if err := s.Scan(int64(1)); !errors.As(err, &decodeErr) {
+		t.Errorf("expected a DecodeError, got %v", err)
+	}
+
+	s = goconstants.MustNewEnumSet(cstMeta, bart)
+	if err := s.Scan(nil); err != nil || s.Len() != 0 {
+		t.Errorf("expected an empty set, got %v (%v)", s, err)
+	}
+}
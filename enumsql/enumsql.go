@@ -0,0 +1,223 @@
+// Package enumsql generates SQL DDL statements from goconstants types, using
+// the values stored by ValueHelper (DBStrings, or integers).
+//
+// CreateType returns a Postgres enumerated type, and Check a CHECK
+// constraint usable with any database. Migrate returns the statements adding
+// the new values to a Postgres type, comparing the constant type with a
+// Snapshot of a previous version:
+//
+//	snapshot := enumsql.NewSnapshot(info) // stored with the migrations
+//	...
+//	statements, err := enumsql.Migrate(snapshot, info)
+//
+// Flags (see goconstants.Metadata.Flags) are not supported, as a column
+// storing combinations of flags can't be restricted to the values.
+package enumsql
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/samonzeweb/goconstants"
+	"github.com/samonzeweb/goconstants/internal/naming"
+)
+
+// Errors returned by the generation functions.
+var (
+	// ErrNotStrings is returned when an enumerated type is generated for a
+	// constant type without DBStrings.
+	ErrNotStrings = errors.New("the values are not stored as strings")
+	// ErrNoValues is returned by Check when the constant type has no values,
+	// as a column can't be restricted to an empty list.
+	ErrNoValues = errors.New("the type has no values")
+	// ErrFlags is returned when the values are flags (see
+	// goconstants.Metadata.Flags), as the stored combinations are not
+	// restricted to the values.
+	ErrFlags = errors.New("the values are combined as flags")
+	// ErrRemovedValue is returned by Migrate when a value of the snapshot
+	// is missing, as values can't be removed from a Postgres type.
+	ErrRemovedValue = errors.New("a value has been removed")
+	// ErrSnapshotMismatch is returned by Migrate when the snapshot is the
+	// one of another constant type.
+	ErrSnapshotMismatch = errors.New("the snapshot is the one of another type")
+)
+
+// TypeName returns the name of the Postgres type of a constant type, its
+// name in snake_case (ie gopher_state), letters with diacritics included.
+// A name without letters or digits is kept as is. The name is always quoted
+// in the statements.
+func TypeName(info goconstants.Info) string {
+	words := naming.Words(info.Name)
+	if len(words) == 0 {
+		return info.Name
+	}
+
+	return strings.ToLower(strings.Join(words, "_"))
+}
+
+// CreateType returns the statement creating the Postgres enumerated type of
+// a constant type, with the values in order:
+//
+//	CREATE TYPE gopher_state AS ENUM ('asleep', 'coding');
+//
+// It returns ErrNotStrings if the values are not stored as strings, and
+// ErrFlags if the values are flags.
+func CreateType(info goconstants.Info) (string, error) {
+	labels, err := labels(info)
+	if err != nil {
+		return "", err
+	}
+
+	literals := make([]string, 0, len(labels))
+	for _, label := range labels {
+		literals = append(literals, quote(label))
+	}
+
+	return fmt.Sprintf("CREATE TYPE %s AS ENUM (%s);", identifier(TypeName(info)), strings.Join(literals, ", ")), nil
+}
+
+// Check returns a CHECK constraint restricting a column to the values of a
+// constant type, strings or integers:
+//
+//	CHECK (state IN ('asleep', 'coding'))
+//
+// The column name is used as is, quote it if needed.
+// It returns ErrNoValues if the constant type has no values, ErrFlags if
+// the values are flags, and goconstants.ErrNotStorable if a value can't be
+// stored.
+func Check(info goconstants.Info, column string) (string, error) {
+	if info.Flags {
+		return "", fmt.Errorf("%w: %s", ErrFlags, info.Name)
+	}
+	if len(info.Values) == 0 {
+		return "", fmt.Errorf("%w: %s", ErrNoValues, info.Name)
+	}
+
+	literals := make([]string, 0, len(info.Values))
+	for _, value := range info.Values {
+		switch v := value.DBValue.(type) {
+		case string:
+			literals = append(literals, quote(v))
+		case int64:
+			literals = append(literals, strconv.FormatInt(v, 10))
+		default:
+			return "", fmt.Errorf("%w: %#v of %s", goconstants.ErrNotStorable, value.Value, info.Name)
+		}
+	}
+
+	return fmt.Sprintf("CHECK (%s IN (%s))", column, strings.Join(literals, ", ")), nil
+}
+
+// Snapshot contains the values of a Postgres enumerated type at a given
+// time, in order. It could be stored as JSON.
+type Snapshot struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
+
+// NewSnapshot returns the snapshot of a constant type. It returns
+// ErrNotStrings if the values are not stored as strings, and ErrFlags if the
+// values are flags.
+func NewSnapshot(info goconstants.Info) (Snapshot, error) {
+	labels, err := labels(info)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	return Snapshot{Name: info.Name, Values: labels}, nil
+}
+
+// Migrate returns the statements adding to the Postgres enumerated type the
+// values missing from the snapshot, at their position:
+//
+//	ALTER TYPE gopher_state ADD VALUE 'eating' AFTER 'asleep';
+//
+// Values can't be removed from a Postgres type, so Migrate returns
+// ErrRemovedValue if a value of the snapshot is missing. Changes of the
+// order of the existing values are ignored.
+func Migrate(previous Snapshot, info goconstants.Info) ([]string, error) {
+	if previous.Name != info.Name {
+		return nil, fmt.Errorf("%w: %s instead of %s", ErrSnapshotMismatch, previous.Name, info.Name)
+	}
+
+	labels, err := labels(info)
+	if err != nil {
+		return nil, err
+	}
+
+	current := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		current[label] = true
+	}
+	existing := make(map[string]bool, len(previous.Values))
+	for _, label := range previous.Values {
+		if !current[label] {
+			return nil, fmt.Errorf("%w: %q of %s", ErrRemovedValue, label, info.Name)
+		}
+		existing[label] = true
+	}
+
+	name := identifier(TypeName(info))
+	var statements []string
+	for i, label := range labels {
+		if existing[label] {
+			continue
+		}
+
+		statement := fmt.Sprintf("ALTER TYPE %s ADD VALUE %s", name, quote(label))
+		if i > 0 {
+			statement += " AFTER " + quote(labels[i-1])
+		} else if next, ok := firstExisting(labels, existing); ok {
+			statement += " BEFORE " + quote(next)
+		}
+		statements = append(statements, statement+";")
+
+		existing[label] = true
+	}
+
+	return statements, nil
+}
+
+// firstExisting returns the first label which already exists.
+func firstExisting(labels []string, existing map[string]bool) (string, bool) {
+	for _, label := range labels {
+		if existing[label] {
+			return label, true
+		}
+	}
+
+	return "", false
+}
+
+// labels returns the strings stored for the values, in order, ErrFlags if
+// the values are flags, or ErrNotStrings if the values are not stored as
+// strings.
+func labels(info goconstants.Info) ([]string, error) {
+	if info.Flags {
+		return nil, fmt.Errorf("%w: %s", ErrFlags, info.Name)
+	}
+
+	labels := make([]string, 0, len(info.Values))
+	for _, value := range info.Values {
+		label, ok := value.DBValue.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrNotStrings, info.Name)
+		}
+		labels = append(labels, label)
+	}
+
+	return labels, nil
+}
+
+// quote returns a SQL string literal.
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// identifier returns a quoted SQL identifier. It's always quoted, as the
+// type name could be a reserved word (ie "order" or "user").
+func identifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
@@ -0,0 +1,228 @@
+package enumsql_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/samonzeweb/goconstants"
+	"github.com/samonzeweb/goconstants/enumsql"
+)
+
+type gopherState int
+
+const (
+	asleep gopherState = iota + 1
+	eating
+	coding
+	joking
+)
+
+// gopherStateInfo returns the description of GopherState restricted to the
+// given values.
+func gopherStateInfo(values ...gopherState) goconstants.Info {
+	all := map[gopherState]string{asleep: "asleep", eating: "eating", coding: "coding", joking: "joke's on you"}
+	meta := goconstants.Metadata[gopherState]{
+		Name:      "GopherState",
+		Strings:   make(map[gopherState]string),
+		DBStrings: make(map[gopherState]string),
+		Order:     values,
+	}
+	for _, v := range values {
+		meta.Strings[v] = all[v]
+		meta.DBStrings[v] = all[v]
+	}
+
+	return meta.Info()
+}
+
+var intInfo = goconstants.Metadata[gopherState]{
+	Name:    "GopherState",
+	Strings: map[gopherState]string{asleep: "asleep", eating: "eating"},
+}.Info()
+
+func TestCreateType(t *testing.T) {
+	statement, err := enumsql.CreateType(gopherStateInfo(asleep, eating, joking))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	expected := `CREATE TYPE "gopher_state" AS ENUM ('asleep', 'eating', 'joke''s on you');`
+	if statement != expected {
+		t.Errorf("expected %s, got %s", expected, statement)
+	}
+
+	order := goconstants.Metadata[string]{
+		Name:      "Order",
+		Strings:   map[string]string{"a": "a"},
+		DBStrings: map[string]string{"a": "a"},
+	}.Info()
+	statement, err = enumsql.CreateType(order)
+	expected = `CREATE TYPE "order" AS ENUM ('a');`
+	if err != nil || statement != expected {
+		t.Errorf("expected %s, got %s (%v)", expected, statement, err)
+	}
+
+	if _, err := enumsql.CreateType(intInfo); !errors.Is(err, enumsql.ErrNotStrings) {
+		t.Errorf("expected error %v, got %v", enumsql.ErrNotStrings, err)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	testCases := []struct {
+		name     string
+		info     goconstants.Info
+		expected string
+	}{
+		{
+			name:     "strings",
+			info:     gopherStateInfo(asleep, coding),
+			expected: "CHECK (state IN ('asleep', 'coding'))",
+		},
+		{
+			name:     "integers",
+			info:     intInfo,
+			expected: "CHECK (state IN (1, 2))",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			check, err := enumsql.Check(testCase.info, "state")
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			if check != testCase.expected {
+				t.Errorf("expected %s, got %s", testCase.expected, check)
+			}
+		})
+	}
+
+	notStorable := goconstants.Metadata[string]{Name: "Strings", Strings: map[string]string{"a": "A"}}.Info()
+	if _, err := enumsql.Check(notStorable, "state"); !errors.Is(err, goconstants.ErrNotStorable) {
+		t.Errorf("expected error %v, got %v", goconstants.ErrNotStorable, err)
+	}
+
+	empty := goconstants.Metadata[int]{Name: "Empty", Strings: map[int]string{}}.Info()
+	if _, err := enumsql.Check(empty, "state"); !errors.Is(err, enumsql.ErrNoValues) {
+		t.Errorf("expected error %v, got %v", enumsql.ErrNoValues, err)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		previous      goconstants.Info
+		current       goconstants.Info
+		expected      []string
+		expectedError error
+	}{
+		{
+			name:     "unchanged",
+			previous: gopherStateInfo(asleep, coding),
+			current:  gopherStateInfo(asleep, coding),
+		},
+		{
+			name:     "added values",
+			previous: gopherStateInfo(coding),
+			current:  gopherStateInfo(asleep, eating, coding, joking),
+			expected: []string{
+				`ALTER TYPE "gopher_state" ADD VALUE 'asleep' BEFORE 'coding';`,
+				`ALTER TYPE "gopher_state" ADD VALUE 'eating' AFTER 'asleep';`,
+				`ALTER TYPE "gopher_state" ADD VALUE 'joke''s on you' AFTER 'coding';`,
+			},
+		},
+		{
+			name:          "removed value",
+			previous:      gopherStateInfo(asleep, coding),
+			current:       gopherStateInfo(asleep),
+			expectedError: enumsql.ErrRemovedValue,
+		},
+		{
+			name:          "other type",
+			previous:      goconstants.Metadata[int]{Name: "Other", DBStrings: map[int]string{1: "a"}, Strings: map[int]string{1: "a"}}.Info(),
+			current:       gopherStateInfo(asleep),
+			expectedError: enumsql.ErrSnapshotMismatch,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			snapshot, err := enumsql.NewSnapshot(testCase.previous)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			statements, err := enumsql.Migrate(snapshot, testCase.current)
+			if !errors.Is(err, testCase.expectedError) {
+				t.Fatalf("expected error %v, got %v", testCase.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(statements, testCase.expected) {
+				t.Errorf("expected %q, got %q", testCase.expected, statements)
+			}
+		})
+	}
+}
+
+func TestSnapshotJSON(t *testing.T) {
+	snapshot, err := enumsql.NewSnapshot(gopherStateInfo(asleep, coding))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	b, err := json.Marshal(snapshot)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	expected := `{"name":"GopherState","values":["asleep","coding"]}`
+	if string(b) != expected {
+		t.Errorf("expected %s, got %s", expected, string(b))
+	}
+
+	if _, err := enumsql.NewSnapshot(intInfo); !errors.Is(err, enumsql.ErrNotStrings) {
+		t.Errorf("expected error %v, got %v", enumsql.ErrNotStrings, err)
+	}
+}
+
+func TestFlags(t *testing.T) {
+	info := goconstants.Metadata[gopherState]{
+		Name:      "GopherState",
+		Strings:   map[gopherState]string{1: "asleep", 2: "coding"},
+		DBStrings: map[gopherState]string{1: "asleep", 2: "coding"},
+		Flags:     true,
+	}.Info()
+
+	if _, err := enumsql.CreateType(info); !errors.Is(err, enumsql.ErrFlags) {
+		t.Errorf("expected error %v, got %v", enumsql.ErrFlags, err)
+	}
+	if _, err := enumsql.Check(info, "state"); !errors.Is(err, enumsql.ErrFlags) {
+		t.Errorf("expected error %v, got %v", enumsql.ErrFlags, err)
+	}
+	if _, err := enumsql.NewSnapshot(info); !errors.Is(err, enumsql.ErrFlags) {
+		t.Errorf("expected error %v, got %v", enumsql.ErrFlags, err)
+	}
+}
+
+func TestTypeName(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{input: "GopherState", expected: "gopher_state"},
+		{input: "HTTPMethod", expected: "http_method"},
+		{input: "t-shirt size", expected: "t_shirt_size"},
+		{input: "ÉtatDuGopher", expected: "état_du_gopher"},
+		{input: "--", expected: "--"},
+	}
+
+	for _, testCase := range testCases {
+		name := enumsql.TypeName(goconstants.Info{Name: testCase.input})
+		if name != testCase.expected {
+			t.Errorf("expected %s, got %s", testCase.expected, name)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+// Package enumts exports goconstants types as TypeScript modules, matching
+// the JSON strings of the values.
+//
+// For each constant type, the module exports:
+//
+//	export type GopherState = "asleep" | "coding";
+//	export const GopherStateValues: readonly GopherState[] = ["asleep", "coding"];
+//	export const GopherStateLabels: Readonly<Record<GopherState, string>> = {...};
+//	export function isGopherState(value: unknown): value is GopherState {...}
+//
+// The values are in order, the labels are the strings of the values, and
+// deprecated values are marked with the @deprecated JSDoc tag.
+//
+// For flags (see goconstants.Metadata.Flags), the declarations above are the
+// ones of the flags (ie PermissionFlag), and the type of the combinations is
+// an array of flags, as marshalled in JSON:
+//
+//	export type Permission = PermissionFlag[];
+//	export function isPermission(value: unknown): value is Permission {...}
+package enumts
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/samonzeweb/goconstants"
+	"github.com/samonzeweb/goconstants/internal/literal"
+	"github.com/samonzeweb/goconstants/internal/naming"
+)
+
+// header starts the generated modules.
+const header = "// Code generated by goconstants; DO NOT EDIT.\n"
+
+// identifierPattern matches the valid TypeScript identifiers (ASCII only).
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// ErrInvalidName is returned when the name of a constant type can't be
+// represented as a TypeScript identifier.
+var ErrInvalidName = errors.New("the name can't be represented as a TypeScript identifier")
+
+// Write writes a TypeScript module defining the given constant types.
+// It returns an error wrapping ErrInvalidName, and writes nothing, if a name
+// can't be represented.
+func Write(w io.Writer, infos ...goconstants.Info) error {
+	names := make([]string, len(infos))
+	for i, info := range infos {
+		name, err := TypeName(info)
+		if err != nil {
+			return err
+		}
+		names[i] = name
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprint(bw, header)
+	for i, info := range infos {
+		writeEnum(bw, info, names[i])
+	}
+
+	return bw.Flush()
+}
+
+// WriteFiles writes a TypeScript module per constant type in the given
+// directory, named after the type (ie GopherState.ts).
+func WriteFiles(dir string, infos ...goconstants.Info) error {
+	for _, info := range infos {
+		name, err := TypeName(info)
+		if err != nil {
+			return err
+		}
+
+		var buf bytes.Buffer
+		if err := Write(&buf, info); err != nil {
+			return err
+		}
+
+		path := filepath.Join(dir, name+".ts")
+		if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TypeName returns the name of the TypeScript type of a constant type, its
+// name if it's a valid identifier, or its name in PascalCase otherwise.
+// It returns an error wrapping ErrInvalidName if the name can't be
+// represented, ie if it has no letters or digits.
+func TypeName(info goconstants.Info) (string, error) {
+	if identifierPattern.MatchString(info.Name) {
+		return info.Name, nil
+	}
+
+	if name, ok := naming.Pascal(info.Name); ok {
+		return name, nil
+	}
+
+	return "", fmt.Errorf("%w: %q", ErrInvalidName, info.Name)
+}
+
+// writeEnum writes the declarations of a constant type, named name. The
+// flags are declared as the values of another type (ie PermissionFlag), the
+// constant type being an array of flags, as marshalled in JSON.
+func writeEnum(w io.Writer, info goconstants.Info, name string) {
+	if !info.Flags {
+		writeValues(w, info, name, "value")
+		return
+	}
+
+	flag := name + "Flag"
+	writeValues(w, info, flag, "flag")
+
+	fmt.Fprintf(w, "\n/** %s is a combination of %s flags. */\n", name, info.Name)
+	fmt.Fprintf(w, "export type %s = %s[];\n", name, flag)
+
+	fmt.Fprintf(w, "\n/** is%s checks if a value is a combination of %s flags. */\n", name, info.Name)
+	fmt.Fprintf(w, "export function is%s(value: unknown): value is %s {\n", name, name)
+	fmt.Fprintf(w, "  return Array.isArray(value) && value.every(is%s);\n", flag)
+	fmt.Fprintf(w, "}\n")
+}
+
+// writeValues writes the declarations of the values of a constant type, in
+// a type named name. The kind names the values in the comments.
+func writeValues(w io.Writer, info goconstants.Info, name string, kind string) {
+	literals := make([]string, 0, len(info.Values))
+	for _, value := range info.Values {
+		literals = append(literals, literal.Quote(value.JSONString))
+	}
+	union := strings.Join(literals, " | ")
+	if union == "" {
+		union = "never"
+	}
+
+	fmt.Fprintf(w, "\n/** %s is the type of the %s %ss. */\n", name, info.Name, kind)
+	fmt.Fprintf(w, "export type %s = %s;\n", name, union)
+
+	fmt.Fprintf(w, "\n/** %sValues contains the %s %ss, in order. */\n", name, info.Name, kind)
+	fmt.Fprintf(w, "export const %sValues: readonly %s[] = [\n", name, name)
+	for _, literal := range literals {
+		fmt.Fprintf(w, "  %s,\n", literal)
+	}
+	fmt.Fprintf(w, "];\n")
+
+	fmt.Fprintf(w, "\n/** %sLabels contains the labels of the %s %ss. */\n", name, info.Name, kind)
+	fmt.Fprintf(w, "export const %sLabels: Readonly<Record<%s, string>> = {\n", name, name)
+	for i, value := range info.Values {
+		if value.Deprecated {
+			fmt.Fprintf(w, "  /** @deprecated %s */\n", deprecation(info, value))
+		}
+		fmt.Fprintf(w, "  %s: %s,\n", literals[i], literal.Quote(value.String))
+	}
+	fmt.Fprintf(w, "};\n")
+
+	fmt.Fprintf(w, "\n/** is%s checks if a value is a %s %s. */\n", name, info.Name, kind)
+	fmt.Fprintf(w, "export function is%s(value: unknown): value is %s {\n", name, name)
+	fmt.Fprintf(w, "  return typeof value === \"string\" && (%sValues as readonly string[]).includes(value);\n", name)
+	fmt.Fprintf(w, "}\n")
+}
+
+// deprecation returns the deprecation note of a value.
+func deprecation(info goconstants.Info, value goconstants.ValueInfo) string {
+	note := info.DeprecationNote(value, func(replacement goconstants.ValueInfo) string {
+		return literal.Quote(replacement.JSONString)
+	})
+
+	return strings.ReplaceAll(note, "*/", "*\\/")
+}
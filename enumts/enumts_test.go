@@ -0,0 +1,128 @@
+package enumts_test
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/samonzeweb/goconstants"
+	"github.com/samonzeweb/goconstants/enumts"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+type gopherState int
+
+const (
+	asleep gopherState = iota + 1
+	coding
+	joking
+)
+
+type size int
+
+type permission uint8
+
+var gopherStateInfo = goconstants.Metadata[gopherState]{
+	Name:        "GopherState",
+	Strings:     map[gopherState]string{asleep: "Zzz", coding: `Coding "hard"`, joking: "Lol"},
+	JSONStrings: map[gopherState]string{asleep: "asleep", coding: "coding", joking: "joking"},
+	Deprecations: map[gopherState]goconstants.Deprecation[gopherState]{
+		joking: {Message: "not a state", Replacement: coding, HasReplacement: true},
+	},
+}.Info()
+
+var sizeInfo = goconstants.Metadata[size]{
+	Name:    "t-shirt size",
+	Strings: map[size]string{1: "S", 2: "M", 3: "L"},
+}.Info()
+
+var permissionInfo = goconstants.Metadata[permission]{
+	Name:        "Permission",
+	Strings:     map[permission]string{1: "Read", 2: "Write"},
+	JSONStrings: map[permission]string{1: "read", 2: "write"},
+	Flags:       true,
+}.Info()
+
+func TestWrite(t *testing.T) {
+	var buf bytes.Buffer
+	if err := enumts.Write(&buf, gopherStateInfo, sizeInfo, permissionInfo); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	golden := filepath.Join("testdata", "enums.golden.ts")
+	if *update {
+		if err := os.WriteFile(golden, buf.Bytes(), 0o644); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+
+	expected, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if buf.String() != string(expected) {
+		t.Errorf("generated code differs from %s:\n%s", golden, buf.String())
+	}
+}
+
+func TestWriteFiles(t *testing.T) {
+	dir := t.TempDir()
+	if err := enumts.WriteFiles(dir, gopherStateInfo, sizeInfo); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	for _, info := range []goconstants.Info{gopherStateInfo, sizeInfo} {
+		name, err := enumts.TypeName(info)
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+
+		content, err := os.ReadFile(filepath.Join(dir, name+".ts"))
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+
+		var expected bytes.Buffer
+		if err := enumts.Write(&expected, info); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if string(content) != expected.String() {
+			t.Errorf("expected %s, got %s", expected.String(), string(content))
+		}
+	}
+}
+
+func TestTypeName(t *testing.T) {
+	testCases := []struct {
+		input         string
+		expected      string
+		expectedError error
+	}{
+		{input: "GopherState", expected: "GopherState"},
+		{input: "t-shirt size", expected: "TShirtSize"},
+		{input: "$ref", expected: "$ref"},
+		{input: "taille de t-shirt très grande", expected: "TailleDeTShirtTresGrande"},
+		{input: "東京", expectedError: enumts.ErrInvalidName},
+		{input: "--", expectedError: enumts.ErrInvalidName},
+	}
+
+	for _, testCase := range testCases {
+		name, err := enumts.TypeName(goconstants.Info{Name: testCase.input})
+		if !errors.Is(err, testCase.expectedError) {
+			t.Errorf("expected error %v, got %v", testCase.expectedError, err)
+		}
+		if name != testCase.expected {
+			t.Errorf("expected %s, got %s", testCase.expected, name)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := enumts.Write(&buf, goconstants.Info{Name: "--"}); !errors.Is(err, enumts.ErrInvalidName) || buf.Len() != 0 {
+		t.Errorf("expected error %v and no output, got %v (%q)", enumts.ErrInvalidName, err, buf.String())
+	}
+}
@@ -0,0 +1,74 @@
+// Code generated by goconstants; DO NOT EDIT.
+
+/** GopherState is the type of the GopherState values. */
+export type GopherState = "asleep" | "coding" | "joking";
+
+/** GopherStateValues contains the GopherState values, in order. */
+export const GopherStateValues: readonly GopherState[] = [
+  "asleep",
+  "coding",
+  "joking",
+];
+
+/** GopherStateLabels contains the labels of the GopherState values. */
+export const GopherStateLabels: Readonly<Record<GopherState, string>> = {
+  "asleep": "Zzz",
+  "coding": "Coding \"hard\"",
+  /** @deprecated not a state, use "coding" */
+  "joking": "Lol",
+};
+
+/** isGopherState checks if a value is a GopherState value. */
+export function isGopherState(value: unknown): value is GopherState {
+  return typeof value === "string" && (GopherStateValues as readonly string[]).includes(value);
+}
+
+/** TShirtSize is the type of the t-shirt size values. */
+export type TShirtSize = "S" | "M" | "L";
+
+/** TShirtSizeValues contains the t-shirt size values, in order. */
+export const TShirtSizeValues: readonly TShirtSize[] = [
+  "S",
+  "M",
+  "L",
+];
+
+/** TShirtSizeLabels contains the labels of the t-shirt size values. */
+export const TShirtSizeLabels: Readonly<Record<TShirtSize, string>> = {
+  "S": "S",
+  "M": "M",
+  "L": "L",
+};
+
+/** isTShirtSize checks if a value is a t-shirt size value. */
+export function isTShirtSize(value: unknown): value is TShirtSize {
+  return typeof value === "string" && (TShirtSizeValues as readonly string[]).includes(value);
+}
+
+/** PermissionFlag is the type of the Permission flags. */
+export type PermissionFlag = "read" | "write";
+
+/** PermissionFlagValues contains the Permission flags, in order. */
+export const PermissionFlagValues: readonly PermissionFlag[] = [
+  "read",
+  "write",
+];
+
+/** PermissionFlagLabels contains the labels of the Permission flags. */
+export const PermissionFlagLabels: Readonly<Record<PermissionFlag, string>> = {
+  "read": "Read",
+  "write": "Write",
+};
+
+/** isPermissionFlag checks if a value is a Permission flag. */
+export function isPermissionFlag(value: unknown): value is PermissionFlag {
+  return typeof value === "string" && (PermissionFlagValues as readonly string[]).includes(value);
+}
+
+/** Permission is a combination of Permission flags. */
+export type Permission = PermissionFlag[];
+
+/** isPermission checks if a value is a combination of Permission flags. */
+export function isPermission(value: unknown): value is Permission {
+  return Array.isArray(value) && value.every(isPermissionFlag);
+}
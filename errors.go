@@ -0,0 +1,72 @@
+package goconstants
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownValue matches (with errors.Is) all errors caused by an unknown
+// constant value or representation.
+var ErrUnknownValue = errors.New("unknown value")
+
+// UnknownValueError is returned when a constant value, or the representation
+// of a constant value, is unknown.
+type UnknownValueError struct {
+	// Enum is the name of the constant type.
+	Enum string
+	// Value is the unknown constant value, nil if a representation was parsed.
+	Value any
+	// Representation is the unknown representation, empty if a constant
+	// value was converted.
+	Representation string
+	// Suggestions are the known representations close to the unknown one,
+	// the closest first. They are not searched for representations longer
+	// than 64 runes.
+	Suggestions []string
+	// Accepted are all known representations, in the order of the values.
+	// It's only set when parsing, if the number of values does not exceed
+	// Metadata.ListAccepted.
+	Accepted []string
+}
+
+// Error implements the error interface.
+func (e *UnknownValueError) Error() string {
+	if e.Value != nil {
+		return fmt.Sprintf("invalid %s value: %#v", e.Enum, e.Value)
+	}
+
+	message := fmt.Sprintf("unknown %s value: %q", e.Enum, e.Representation)
+	if len(e.Suggestions) > 0 {
+		message += fmt.Sprintf(", did you mean %s?", formatList(e.Suggestions))
+	} else if len(e.Accepted) > 0 {
+		message += fmt.Sprintf(", accepted values are %s", formatList(e.Accepted))
+	}
+
+	return message
+}
+
+// Is allows errors.Is to match ErrUnknownValue.
+func (e *UnknownValueError) Is(target error) bool {
+	return target == ErrUnknownValue
+}
+
+// DecodeError is returned when the encoded content is malformed, before
+// trying to find the associated constant value.
+type DecodeError struct {
+	// Enum is the name of the constant type.
+	Enum string
+	// Format is the name of the encoding format (ie "json").
+	Format string
+	// Err is the error returned by the decoder.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("unable to decode %s value from %s: %v", e.Enum, e.Format, e.Err)
+}
+
+// Unwrap returns the error returned by the decoder.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,91 @@
+package goconstants_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/samonzeweb/goconstants"
+)
+
+func TestUnknownValueError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            error
+		expected       goconstants.UnknownValueError
+		expectedString string
+	}{
+		{
+			name: "to string",
+			err: func() error {
+				_, err := cstMeta.ToStringHelper(999)
+				return err
+			}(),
+			expected:       goconstants.UnknownValueError{Enum: "cst", Value: simpson(999)},
+			expectedString: "invalid cst value: 999",
+		},
+		{
+			name: "marshal json",
+			err: func() error {
+				_, err := cstMeta.MarshalJSONHelper(999)
+				return err
+			}(),
+			expected:       goconstants.UnknownValueError{Enum: "cst", Value: simpson(999)},
+			expectedString: "unable to mashal cst type to json: invalid cst value: 999",
+		},
+		{
+			name: "unmarshal json",
+			err: func() error {
+				var value simpson
+				return cstMeta.UnmarshalJSONHelper([]byte(`"ned_flanders"`), &value)
+			}(),
+			expected:       goconstants.UnknownValueError{Enum: "cst", Representation: "ned_flanders"},
+			expectedString: `unknown cst value: "ned_flanders"`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if !errors.Is(testCase.err, goconstants.ErrUnknownValue) {
+				t.Errorf("expected error to match ErrUnknownValue")
+			}
+
+			var unknownValueError *goconstants.UnknownValueError
+			if !errors.As(testCase.err, &unknownValueError) {
+				t.Fatalf("expected an UnknownValueError, got %#v", testCase.err)
+			}
+
+			if !reflect.DeepEqual(*unknownValueError, testCase.expected) {
+				t.Errorf("expected %#v, got %#v", testCase.expected, *unknownValueError)
+			}
+
+			if testCase.err.Error() != testCase.expectedString {
+				t.Errorf("expected error %s, got %s", testCase.expectedString, testCase.err.Error())
+			}
+		})
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+	var value simpson
+	err := cstMeta.UnmarshalJSONHelper([]byte(`42`), &value)
+
+	if errors.Is(err, goconstants.ErrUnknownValue) {
+		t.Errorf("malformed content should not match ErrUnknownValue")
+	}
+
+	var decodeError *goconstants.DecodeError
+	if !errors.As(err, &decodeError) {
+		t.Fatalf("expected a DecodeError, got %#v", err)
+	}
+
+	if decodeError.Enum != "cst" || decodeError.Format != "json" {
+		t.Errorf("unexpected error content %#v", decodeError)
+	}
+
+	var typeError *json.UnmarshalTypeError
+	if !errors.As(err, &typeError) {
+		t.Errorf("expected the json error to be wrapped, got %#v", decodeError.Err)
+	}
+}
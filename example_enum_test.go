@@ -0,0 +1,94 @@
+package goconstants_test
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/samonzeweb/goconstants"
+)
+
+// planet is the non exported constant type of Planet, values can't be
+// created outside of the package.
+type planet int
+
+// Planet is an enumeration of planets, all methods are provided by
+// goconstants.Enum, using the descriptor bound to planet.
+type Planet = goconstants.Enum[planet]
+
+// Values of planet, only used inside of the package.
+const (
+	mercury planet = 1 + iota
+	venus
+	earth
+)
+
+// All valid values for the Planet type.
+var (
+	Mercury = goconstants.EnumOf(mercury)
+	Venus   = goconstants.EnumOf(venus)
+	Earth   = goconstants.EnumOf(earth)
+)
+
+// planets is the descriptor bound to planet, its visibility is limited.
+var planets = goconstants.MustBind(goconstants.MustNew("Planet",
+	goconstants.WithStrings(map[planet]string{
+		mercury: "Mercury",
+		venus:   "Venus",
+		earth:   "Earth",
+	}),
+	goconstants.WithJSONStrings(map[planet]string{
+		mercury: "mercury",
+		venus:   "venus",
+		earth:   "earth",
+	}),
+))
+
+func Example_enum() {
+	// Convert to strings
+	// Using a valid constant value.
+	home := Earth
+	fmt.Println(home)
+	fmt.Println(home.ToString())
+	fmt.Println(home.IsValid())
+
+	// Using an invalid constant.
+	// home = 999 // will not compile
+	// home = goconstants.EnumOf(999) // will not compile either
+	invalidPlanet := Planet{} // will compile !
+	fmt.Println(invalidPlanet.ToString())
+	fmt.Println(invalidPlanet.IsValid())
+
+	// Parsing with the descriptor
+	fmt.Println(planets.FromStringHelper("Venus"))
+
+	// Convert to / from JSON
+	type Probe struct {
+		Name   string `json:"name"`
+		Target Planet `json:"target"`
+	}
+
+	probe := Probe{
+		Name:   "Messenger",
+		Target: Mercury,
+	}
+
+	rawJson, err := json.Marshal(probe)
+	fmt.Println(string(rawJson))
+	fmt.Println(err)
+
+	err = json.Unmarshal([]byte(`{"name":"Magellan","target":"venus"}`), &probe)
+	fmt.Println(probe.Name, probe.Target, probe.Target == Venus)
+	fmt.Println(err)
+
+	// Output:
+	// Earth
+	// Earth <nil>
+	// true
+	//  invalid Planet value: 0
+	// false
+	// 2 true
+	// {"name":"Messenger","target":"mercury"}
+	// <nil>
+	// Magellan Venus true
+	// <nil>
+}
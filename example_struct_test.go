@@ -1,6 +1,7 @@
 package goconstants_test
 
 import (
+	"database/sql/driver"
 	"encoding/json"
 	"fmt"
 
@@ -11,8 +12,9 @@ import (
 // Using a struct with a non exported int lead to a safer constant type
 // as it's not possible to affect a value of another type though implicit
 // conversion.
-// It will be less convenient when used with SQL, and will requires the
-// implementation of sql.Scanner and driver.Valuer interfaces.
+// It will be less convenient when used with SQL, and requires the
+// implementation of sql.Scanner and driver.Valuer interfaces, using DBStrings
+// as there is no integer to store.
 type LanguageEnum struct {
 	value int
 }
@@ -36,6 +38,11 @@ var metaLanguageEnum = goconstants.Metadata[LanguageEnum]{
 		Rust:   "Rust",
 		Python: "Python",
 	},
+	DBStrings: map[LanguageEnum]string{
+		Go:     "go",
+		Rust:   "rust",
+		Python: "python",
+	},
 }
 
 // String returns a string representation of the constant.
@@ -66,6 +73,16 @@ func (gs *LanguageEnum) UnmarshalJSON(b []byte) error {
 	return metaLanguageEnum.UnmarshalJSONHelper(b, gs)
 }
 
+// Value implements driver.Valuer
+func (gs LanguageEnum) Value() (driver.Value, error) {
+	return metaLanguageEnum.ValueHelper(gs)
+}
+
+// Scan implements sql.Scanner
+func (gs *LanguageEnum) Scan(src any) error {
+	return metaLanguageEnum.ScanHelper(src, gs)
+}
+
 func Example_struct() {
 	// Convert to / from strings
 	// Using a valid constant value.
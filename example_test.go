@@ -67,6 +67,17 @@ func (gs *GopherState) UnmarshalJSON(b []byte) error {
 	return metaGopherState.UnmarshalJSONHelper(b, gs)
 }
 
+// MarshalText implements encoding.TextMarshaler, allowing the use of
+// GopherState as JSON map keys.
+func (gs GopherState) MarshalText() ([]byte, error) {
+	return metaGopherState.MarshalTextHelper(gs)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (gs *GopherState) UnmarshalText(b []byte) error {
+	return metaGopherState.UnmarshalTextHelper(b, gs)
+}
+
 func Example_int() {
 	// Convert to / from strings
 	// Using a valid constant value.
@@ -0,0 +1,22 @@
+package goconstants
+
+import (
+	"reflect"
+	"testing"
+)
+
+// IsolateRegistry empties the registry for the duration of the test, the
+// registered constant types are restored by t.Cleanup.
+func IsolateRegistry(t testing.TB) {
+	registry.Lock()
+	byName, byType := registry.byName, registry.byType
+	registry.byName = make(map[string]*entry)
+	registry.byType = make(map[reflect.Type]*entry)
+	registry.Unlock()
+
+	t.Cleanup(func() {
+		registry.Lock()
+		registry.byName, registry.byType = byName, byType
+		registry.Unlock()
+	})
+}
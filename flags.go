@@ -0,0 +1,284 @@
+package goconstants
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// FlagSeparator separates the flags of a combination in its string
+// representations (ie "Read|Write").
+const FlagSeparator = "|"
+
+// Errors returned by Validate when Flags is set.
+var (
+	ErrFlagsNotInteger = errors.New("flags require an integer type")
+	ErrNotSingleBit    = errors.New("flags must be single bits")
+	ErrFlagSeparator   = errors.New("flag strings can't contain the separator")
+)
+
+// UnknownBitsError is returned when a combination of flags contains bits
+// which are not known values.
+type UnknownBitsError struct {
+	// Enum is the name of the constant type.
+	Enum string
+	// Value is the combination of flags.
+	Value any
+	// Bits are the unknown bits of the combination.
+	Bits uint64
+}
+
+// Error implements the error interface.
+func (e *UnknownBitsError) Error() string {
+	return fmt.Sprintf("invalid %s value: %#v (unknown bits %#x)", e.Enum, e.Value, e.Bits)
+}
+
+// Is allows errors.Is to match ErrUnknownValue.
+func (e *UnknownBitsError) Is(target error) bool {
+	return target == ErrUnknownValue
+}
+
+// flagLayout contains the bits of the known values, computed once by
+// Compile.
+type flagLayout[T comparable] struct {
+	// flags are the known values which are not the zero value, in the order
+	// of Values.
+	flags []T
+	// bits are the bits of the known values.
+	bits map[T]uint64
+	// known is the union of the bits of the known values.
+	known uint64
+}
+
+// getFlagLayout returns the bits of the known values, from the index if the
+// Metadata is compiled.
+func (meta Metadata[T]) getFlagLayout() *flagLayout[T] {
+	if meta.index != nil && meta.index.flags != nil {
+		return meta.index.flags
+	}
+
+	return meta.newFlagLayout()
+}
+
+// newFlagLayout computes the bits of the known values.
+func (meta Metadata[T]) newFlagLayout() *flagLayout[T] {
+	values := meta.getValues()
+	layout := &flagLayout[T]{bits: make(map[T]uint64, len(values))}
+	for _, v := range values {
+		b, _ := toBits(v)
+		layout.bits[v] = b
+		layout.known |= b
+		if b != 0 {
+			layout.flags = append(layout.flags, v)
+		}
+	}
+
+	return layout
+}
+
+// splitFlags returns the known values composing a combination of flags, in
+// the order of Values. A known value is returned as is, and the zero value
+// is an empty combination if it's not a known value.
+func (meta Metadata[T]) splitFlags(v T) ([]T, error) {
+	layout := meta.getFlagLayout()
+	if _, ok := layout.bits[v]; ok {
+		return []T{v}, nil
+	}
+
+	bits, _ := toBits(v)
+	if unknown := bits &^ layout.known; unknown != 0 {
+		return nil, &UnknownBitsError{Enum: meta.Name, Value: v, Bits: unknown}
+	}
+
+	var flags []T
+	for _, flag := range layout.flags {
+		if b := layout.bits[flag]; bits&b == b {
+			flags = append(flags, flag)
+		}
+	}
+
+	return flags, nil
+}
+
+// formatFlags returns the representation of a combination of flags, the
+// representations of the flags joined by FlagSeparator.
+func (meta Metadata[T]) formatFlags(v T, r Representation) (string, error) {
+	flags, err := meta.splitFlags(v)
+	if err != nil {
+		return "", err
+	}
+
+	representations := meta.getRepresentation(r)
+	parts := make([]string, len(flags))
+	for i, flag := range flags {
+		parts[i] = representations[flag]
+	}
+
+	return strings.Join(parts, FlagSeparator), nil
+}
+
+// decodeFlags converts the representation of a combination of flags, an
+// empty string being the empty combination. Each flag is decoded as a single
+// value.
+func (meta Metadata[T]) decodeFlags(representation string, r Representation) (T, error) {
+	if representation == "" {
+		var zero T
+		return zero, nil
+	}
+
+	return meta.combineFlags(strings.Split(representation, FlagSeparator), r)
+}
+
+// combineFlags decodes the representations of flags and combines them.
+func (meta Metadata[T]) combineFlags(representations []string, r Representation) (T, error) {
+	layout := meta.getFlagLayout()
+	var bits uint64
+	for _, representation := range representations {
+		flag, err := meta.decode(representation, r)
+		if err != nil {
+			var zero T
+			return zero, err
+		}
+
+		bits |= layout.bits[flag]
+	}
+
+	return fromBits[T](bits), nil
+}
+
+// marshalFlagsJSON returns the JSON array of the JSON strings of a
+// combination of flags.
+func (meta Metadata[T]) marshalFlagsJSON(v T) ([]byte, error) {
+	flags, err := meta.splitFlags(v)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonStrings := meta.getJSONStrings()
+	representations := make([]string, len(flags))
+	for i, flag := range flags {
+		representations[i] = jsonStrings[flag]
+	}
+
+	return json.Marshal(representations)
+}
+
+// unmarshalFlagsJSON converts a JSON array of JSON strings, or a JSON string
+// containing the JSON strings joined by FlagSeparator, to a combination of
+// flags.
+func (meta Metadata[T]) unmarshalFlagsJSON(b []byte) (T, error) {
+	var representations []string
+	if err := json.Unmarshal(b, &representations); err == nil {
+		return meta.combineFlags(representations, JSONRepresentation)
+	}
+
+	var representation string
+	if err := json.Unmarshal(b, &representation); err != nil {
+		var zero T
+		return zero, &DecodeError{Enum: meta.Name, Format: "json", Err: err}
+	}
+
+	return meta.decodeFlags(representation, JSONRepresentation)
+}
+
+// applyFlagsPolicy applies a deprecation policy to each flag of a
+// combination.
+func (meta Metadata[T]) applyFlagsPolicy(v T, policy DeprecationPolicy) (T, error) {
+	flags, err := meta.splitFlags(v)
+	if err != nil || len(flags) < 2 {
+		return v, nil
+	}
+
+	layout := meta.getFlagLayout()
+	var bits uint64
+	for _, flag := range flags {
+		flag, err := meta.applyPolicy(flag, policy)
+		if err != nil {
+			return v, err
+		}
+
+		bits |= layout.bits[flag]
+	}
+
+	return fromBits[T](bits), nil
+}
+
+// invalidValue returns the error of a value which is not valid.
+func (meta Metadata[T]) invalidValue(v T) error {
+	if meta.Flags {
+		if _, err := meta.splitFlags(v); err != nil {
+			return err
+		}
+	}
+
+	return &UnknownValueError{Enum: meta.Name, Value: v}
+}
+
+// flagsProblems returns the problems of the values when Flags is set.
+func (meta Metadata[T]) flagsProblems() []error {
+	if !meta.Flags {
+		return nil
+	}
+
+	var zero T
+	if _, ok := toBits(zero); !ok {
+		return []error{fmt.Errorf("%w: %T", ErrFlagsNotInteger, zero)}
+	}
+
+	var problems []error
+	for _, v := range sortedKeys(meta.getStrings()) {
+		if b, _ := toBits(v); b&(b-1) != 0 {
+			problems = append(problems, fmt.Errorf("%w: %#v", ErrNotSingleBit, v))
+		}
+	}
+
+	for _, representations := range []map[T]string{meta.Strings, meta.JSONStrings, meta.DBStrings} {
+		for _, v := range sortedKeys(representations) {
+			if strings.Contains(representations[v], FlagSeparator) {
+				problems = append(problems, fmt.Errorf("%w: %q", ErrFlagSeparator, representations[v]))
+			}
+		}
+	}
+
+	for _, aliases := range []map[T][]string{meta.Aliases, meta.JSONAliases} {
+		for _, v := range sortedKeys(aliases) {
+			for _, alias := range aliases[v] {
+				if strings.Contains(alias, FlagSeparator) {
+					problems = append(problems, fmt.Errorf("%w: alias %q", ErrFlagSeparator, alias))
+				}
+			}
+		}
+	}
+
+	return problems
+}
+
+// toBits returns the bits of a value of an integer type. The boolean is
+// false if the type is not an integer type.
+func toBits[T comparable](v T) (uint64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return uint64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return rv.Uint(), true
+	default:
+		return 0, false
+	}
+}
+
+// fromBits returns the value of an integer type having the given bits.
+func fromBits[T comparable](bits uint64) T {
+	var value T
+	rv := reflect.ValueOf(&value).Elem()
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		rv.SetInt(int64(bits))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		rv.SetUint(bits)
+	}
+
+	return value
+}
@@ -0,0 +1,356 @@
+package goconstants_test
+
+import (
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/samonzeweb/goconstants"
+)
+
+type permission uint8
+
+const (
+	read permission = 1 << iota
+	write
+	exec
+	admin
+)
+
+var permissionMeta = goconstants.Metadata[permission]{
+	Name:        "permission",
+	Strings:     map[permission]string{read: "Read", write: "Write", exec: "Exec"},
+	JSONStrings: map[permission]string{read: "read", write: "write", exec: "exec"},
+	Flags:       true,
+}
+
+func TestFlagsIsValidHelper(t *testing.T) {
+	testCases := []struct {
+		input    permission
+		expected bool
+	}{
+		{input: 0, expected: true},
+		{input: read, expected: true},
+		{input: read | write, expected: true},
+		{input: read | write | exec, expected: true},
+		{input: admin, expected: false},
+		{input: read | admin, expected: false},
+	}
+
+	for _, testCase := range testCases {
+		if valid := permissionMeta.IsValidHelper(testCase.input); valid != testCase.expected {
+			t.Errorf("expected %t for %v, got %t", testCase.expected, testCase.input, valid)
+		}
+	}
+}
+
+func TestFlagsToStringHelper(t *testing.T) {
+	testCases := []struct {
+		input    permission
+		expected string
+	}{
+		{input: 0, expected: ""},
+		{input: write, expected: "Write"},
+		{input: exec | read, expected: "Read|Exec"},
+		{input: read | write | exec, expected: "Read|Write|Exec"},
+	}
+
+	for _, testCase := range testCases {
+		s, err := permissionMeta.ToStringHelper(testCase.input)
+		if err != nil {
+			t.Errorf("unexpected error %v", err)
+		}
+		if s != testCase.expected {
+			t.Errorf("expected %q, got %q", testCase.expected, s)
+		}
+	}
+
+	_, err := permissionMeta.ToStringHelper(read | admin | 0x80)
+	var bitsErr *goconstants.UnknownBitsError
+	if !errors.As(err, &bitsErr) {
+		t.Fatalf("expected an UnknownBitsError, got %v", err)
+	}
+	if bitsErr.Bits != 0x88 || bitsErr.Value != read|admin|0x80 {
+		t.Errorf("unexpected error content %#v", bitsErr)
+	}
+	if !errors.Is(err, goconstants.ErrUnknownValue) {
+		t.Errorf("expected error %v, got %v", goconstants.ErrUnknownValue, err)
+	}
+}
+
+func TestFlagsKnownZero(t *testing.T) {
+	meta := permissionMeta
+	meta.Strings = map[permission]string{0: "None", read: "Read", write: "Write", exec: "Exec"}
+	meta.JSONStrings = nil
+
+	if s := meta.StringHelper(0); s != "None" {
+		t.Errorf("expected None, got %q", s)
+	}
+	if s := meta.StringHelper(read | write); s != "Read|Write" {
+		t.Errorf("expected Read|Write, got %q", s)
+	}
+	if value, ok := meta.FromStringHelper("None"); !ok || value != 0 {
+		t.Errorf("expected 0, got %v (%t)", value, ok)
+	}
+}
+
+func TestFlagsParseHelper(t *testing.T) {
+	testCases := []struct {
+		input         string
+		expected      permission
+		expectedError error
+	}{
+		{input: "", expected: 0},
+		{input: "Read", expected: read},
+		{input: "Write|Read", expected: read | write},
+		{input: "Read|Read", expected: read},
+		{input: "Read|Admin", expectedError: goconstants.ErrUnknownValue},
+		{input: "Read|", expectedError: goconstants.ErrUnknownValue},
+	}
+
+	for _, testCase := range testCases {
+		value, err := permissionMeta.ParseHelper(testCase.input)
+		if !errors.Is(err, testCase.expectedError) {
+			t.Errorf("expected error %v for %q, got %v", testCase.expectedError, testCase.input, err)
+		}
+		if err == nil && value != testCase.expected {
+			t.Errorf("expected %v for %q, got %v", testCase.expected, testCase.input, value)
+		}
+	}
+
+	if _, ok := permissionMeta.FromStringHelper("Read|Admin"); ok {
+		t.Errorf("unknown flags should not be parsed")
+	}
+}
+
+func TestFlagsJSON(t *testing.T) {
+	testCases := []struct {
+		value permission
+		json  string
+	}{
+		{value: 0, json: `[]`},
+		{value: read, json: `["read"]`},
+		{value: read | write, json: `["read","write"]`},
+	}
+
+	for _, testCase := range testCases {
+		b, err := permissionMeta.MarshalJSONHelper(testCase.value)
+		if err != nil {
+			t.Errorf("unexpected error %v", err)
+		}
+		if string(b) != testCase.json {
+			t.Errorf("expected %s, got %s", testCase.json, string(b))
+		}
+
+		var value permission
+		if err := permissionMeta.UnmarshalJSONHelper([]byte(testCase.json), &value); err != nil {
+			t.Errorf("unexpected error %v", err)
+		}
+		if value != testCase.value {
+			t.Errorf("expected %v, got %v", testCase.value, value)
+		}
+	}
+
+	var value permission
+	if err := permissionMeta.UnmarshalJSONHelper([]byte(`"exec|read"`), &value); err != nil || value != read|exec {
+		t.Errorf("expected %v, got %v (%v)", read|exec, value, err)
+	}
+
+	if err := permissionMeta.UnmarshalJSONHelper([]byte(`["read","admin"]`), &value); !errors.Is(err, goconstants.ErrUnknownValue) {
+		t.Errorf("expected error %v, got %v", goconstants.ErrUnknownValue, err)
+	}
+
+	var decodeErr *goconstants.DecodeError
+	if err := permissionMeta.UnmarshalJSONHelper([]byte(`[1]`), &value); !errors.As(err, &decodeErr) {
+		t.Errorf("expected a DecodeError, got %v", err)
+	}
+
+	if _, err := permissionMeta.MarshalJSONHelper(admin); !errors.Is(err, goconstants.ErrUnknownValue) {
+		t.Errorf("expected error %v, got %v", goconstants.ErrUnknownValue, err)
+	}
+}
+
+func TestFlagsText(t *testing.T) {
+	b, err := permissionMeta.MarshalTextHelper(read | exec)
+	if err != nil || string(b) != "read|exec" {
+		t.Errorf("expected read|exec, got %s (%v)", string(b), err)
+	}
+
+	var value permission
+	if err := permissionMeta.UnmarshalTextHelper([]byte("exec|write"), &value); err != nil || value != write|exec {
+		t.Errorf("expected %v, got %v (%v)", write|exec, value, err)
+	}
+}
+
+func TestFlagsSQL(t *testing.T) {
+	testCases := []struct {
+		name      string
+		dbStrings map[permission]string
+		value     permission
+		stored    driver.Value
+	}{
+		{name: "integer", value: read | exec, stored: int64(5)},
+		{
+			name:      "strings",
+			dbStrings: map[permission]string{read: "r", write: "w", exec: "x"},
+			value:     read | exec,
+			stored:    "r|x",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			meta := permissionMeta
+			meta.DBStrings = testCase.dbStrings
+
+			stored, err := meta.ValueHelper(testCase.value)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if stored != testCase.stored {
+				t.Errorf("expected %#v, got %#v", testCase.stored, stored)
+			}
+
+			var value permission
+			if err := meta.ScanHelper(stored, &value); err != nil || value != testCase.value {
+				t.Errorf("expected %v, got %v (%v)", testCase.value, value, err)
+			}
+		})
+	}
+
+	var value permission
+	var bitsErr *goconstants.UnknownBitsError
+	if err := permissionMeta.ScanHelper(int64(read|admin), &value); !errors.As(err, &bitsErr) || bitsErr.Bits != uint64(admin) {
+		t.Errorf("expected an UnknownBitsError, got %v", err)
+	}
+	if _, err := permissionMeta.ValueHelper(read | admin); !errors.As(err, &bitsErr) {
+		t.Errorf("expected an UnknownBitsError, got %v", err)
+	}
+}
+
+func TestFlagsDeprecations(t *testing.T) {
+	meta := permissionMeta
+	meta.Deprecations = map[permission]goconstants.Deprecation[permission]{
+		exec: {Message: "use write", Replacement: write, HasReplacement: true},
+	}
+	meta.MarshalDeprecated = goconstants.ReplaceDeprecated
+
+	if err := meta.Validate(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	b, err := meta.MarshalJSONHelper(read | exec)
+	if err != nil || string(b) != `["read","write"]` {
+		t.Errorf(`expected ["read","write"], got %s (%v)`, string(b), err)
+	}
+
+	meta.ParseDeprecated = goconstants.RejectDeprecated
+
+	var value permission
+	if err := meta.UnmarshalJSONHelper([]byte(`["read","exec"]`), &value); !errors.Is(err, goconstants.ErrDeprecatedValue) {
+		t.Errorf("expected error %v, got %v", goconstants.ErrDeprecatedValue, err)
+	}
+	if err := meta.ScanHelper(int64(read|exec), &value); !errors.Is(err, goconstants.ErrDeprecatedValue) {
+		t.Errorf("expected error %v, got %v", goconstants.ErrDeprecatedValue, err)
+	}
+}
+
+func TestFlagsDescriptor(t *testing.T) {
+	descriptor, err := goconstants.New("permission",
+		goconstants.WithStrings(map[permission]string{read: "Read", write: "Write"}),
+		goconstants.WithFlags[permission]())
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if value, err := descriptor.ParseHelper("Read|Write"); err != nil || value != read|write {
+		t.Errorf("expected %v, got %v (%v)", read|write, value, err)
+	}
+}
+
+func TestFlagsCompiled(t *testing.T) {
+	meta := permissionMeta.Compile()
+
+	if s, err := meta.ToStringHelper(exec | read); err != nil || s != "Read|Exec" {
+		t.Errorf("expected Read|Exec, got %q (%v)", s, err)
+	}
+	if value, err := meta.ParseHelper("Exec|Write"); err != nil || value != write|exec {
+		t.Errorf("expected %v, got %v (%v)", write|exec, value, err)
+	}
+
+	var bitsErr *goconstants.UnknownBitsError
+	if _, err := meta.ToStringHelper(read | admin); !errors.As(err, &bitsErr) || bitsErr.Bits != uint64(admin) {
+		t.Errorf("expected an UnknownBitsError, got %v", err)
+	}
+}
+
+func TestFlagsInfo(t *testing.T) {
+	if !permissionMeta.Info().Flags {
+		t.Errorf("expected Flags to be set")
+	}
+	if cstMeta.Info().Flags {
+		t.Errorf("expected Flags not to be set")
+	}
+}
+
+func TestValidateFlags(t *testing.T) {
+	testCases := []struct {
+		name          string
+		meta          func() error
+		expectedError error
+	}{
+		{
+			name: "not integer",
+			meta: func() error {
+				return goconstants.Metadata[string]{
+					Name:    "letter",
+					Strings: map[string]string{"a": "A"},
+					Flags:   true,
+				}.Validate()
+			},
+			expectedError: goconstants.ErrFlagsNotInteger,
+		},
+		{
+			name: "not single bit",
+			meta: func() error {
+				meta := permissionMeta
+				meta.Strings = map[permission]string{read: "Read", write: "Write", read | write: "ReadWrite"}
+				meta.JSONStrings = nil
+				return meta.Validate()
+			},
+			expectedError: goconstants.ErrNotSingleBit,
+		},
+		{
+			name: "separator",
+			meta: func() error {
+				meta := permissionMeta
+				meta.JSONStrings = map[permission]string{read: "read", write: "write", exec: "exec|run"}
+				return meta.Validate()
+			},
+			expectedError: goconstants.ErrFlagSeparator,
+		},
+		{
+			name: "alias separator",
+			meta: func() error {
+				meta := permissionMeta
+				meta.JSONAliases = map[permission][]string{exec: {"run|exec"}}
+				return meta.Validate()
+			},
+			expectedError: goconstants.ErrFlagSeparator,
+		},
+		{
+			name: "valid",
+			meta: func() error {
+				return permissionMeta.Validate()
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if err := testCase.meta(); !errors.Is(err, testCase.expectedError) {
+				t.Errorf("expected error %v, got %v", testCase.expectedError, err)
+			}
+		})
+	}
+}
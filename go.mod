@@ -1,3 +1,5 @@
 module github.com/samonzeweb/goconstants
 
-go 1.18
+go 1.23
+
+require gopkg.in/yaml.v3 v3.0.1
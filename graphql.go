@@ -0,0 +1,199 @@
+package goconstants
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/samonzeweb/goconstants/internal/naming"
+)
+
+// Errors returned for invalid GraphQL names.
+var (
+	ErrInvalidGraphQLName        = errors.New("invalid GraphQL name")
+	ErrGraphQLStringsIncoherence = errors.New("GraphQLStrings does not have the same keys than other strings")
+	ErrDuplicateGraphQLString    = errors.New("several values share the same GraphQL name")
+)
+
+// GraphQLNameHelper returns the GraphQL name of a constant value, applying
+// the MarshalDeprecated policy.
+// If GraphQLStrings is not set, it returns an error wrapping
+// ErrInvalidGraphQLName or ErrDuplicateGraphQLString if the name derived
+// from the JSON string is not a valid or distinct GraphQL name.
+func (meta Metadata[T]) GraphQLNameHelper(v T) (string, error) {
+	v, err := meta.marshalled(v)
+	if err != nil {
+		return "", err
+	}
+
+	name, err := meta.toStringHelper(v, meta.getGraphQLStrings())
+	if err != nil {
+		return "", err
+	}
+
+	if err := meta.derivedGraphQLProblems()[v]; err != nil {
+		return "", err
+	}
+
+	return name, nil
+}
+
+// FromGraphQLNameHelper converts a GraphQL name to its associated constant
+// value, applying the ParseDeprecated policy.
+// It returns an UnknownValueError if the name is unknown, and the errors of
+// GraphQLNameHelper if the derived name is not valid or distinct.
+func (meta Metadata[T]) FromGraphQLNameHelper(name string) (T, error) {
+	value, err := meta.decode(name, GraphQLRepresentation)
+	if err != nil {
+		return value, err
+	}
+
+	if err := meta.derivedGraphQLProblems()[value]; err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return value, nil
+}
+
+// MarshalGQLHelper allows the implementation of the graphql.Marshaler
+// interface of gqlgen for the associated constant type, writing the GraphQL
+// name as a JSON string, or null if the value is unknown.
+func (meta Metadata[T]) MarshalGQLHelper(v T, w io.Writer) {
+	name, err := meta.GraphQLNameHelper(v)
+	if err != nil {
+		_, _ = io.WriteString(w, "null")
+		return
+	}
+
+	b, _ := json.Marshal(name)
+	_, _ = w.Write(b)
+}
+
+// UnmarshalGQLHelper allows the implementation of the graphql.Unmarshaler
+// interface of gqlgen for the associated constant type. The source must be
+// a string containing a GraphQL name.
+// It returns a DecodeError if the source is not a string, and an
+// UnknownValueError if the name is unknown.
+func (meta Metadata[T]) UnmarshalGQLHelper(src any, v *T) error {
+	name, ok := src.(string)
+	if !ok {
+		return &DecodeError{
+			Enum:   meta.Name,
+			Format: "graphql",
+			Err:    fmt.Errorf("unsupported source type %T", src),
+		}
+	}
+
+	value, err := meta.FromGraphQLNameHelper(name)
+	if err != nil {
+		return err
+	}
+
+	*v = value
+	return nil
+}
+
+// getGraphQLStrings returns GraphQLStrings if set, or the JSON strings in
+// SCREAMING_SNAKE_CASE otherwise, prefixed by an underscore if they start
+// with a digit. The derived names are computed once by Compile.
+func (meta Metadata[T]) getGraphQLStrings() map[T]string {
+	if meta.index != nil {
+		return meta.index.graphQLNames
+	}
+
+	if meta.GraphQLStrings != nil {
+		return meta.GraphQLStrings
+	}
+
+	jsonStrings := meta.getJSONStrings()
+	names := make(map[T]string, len(jsonStrings))
+	for v, s := range jsonStrings {
+		// A JSON string which can't be represented is kept, for
+		// derivedGraphQLProblems to report it.
+		names[v] = s
+		if name, ok := naming.ScreamingSnake(s); ok {
+			names[v] = name
+		}
+	}
+
+	return names
+}
+
+// graphQLProblems returns the problems of GraphQLStrings. The derived names
+// are only checked when they are used (see GraphQLNameHelper), as types not
+// exported to GraphQL don't need valid names.
+func (meta Metadata[T]) graphQLProblems() []error {
+	if meta.GraphQLStrings == nil {
+		return nil
+	}
+
+	var problems []error
+	if !sameKeys(meta.getStrings(), meta.GraphQLStrings) {
+		problems = append(problems, ErrGraphQLStringsIncoherence)
+	}
+
+	for _, v := range sortedKeys(meta.GraphQLStrings) {
+		if name := meta.GraphQLStrings[v]; !IsGraphQLName(name) {
+			problems = append(problems, fmt.Errorf("%w: %q for %#v", ErrInvalidGraphQLName, name, v))
+		}
+	}
+
+	return append(problems, duplicates(meta.GraphQLStrings, meta.Parsing, ErrDuplicateGraphQLString)...)
+}
+
+// derivedGraphQLProblems returns the problems of the GraphQL names derived
+// from the JSON strings, by value, if GraphQLStrings is not set. They are
+// computed once by Compile.
+func (meta Metadata[T]) derivedGraphQLProblems() map[T]error {
+	if meta.index != nil {
+		return meta.index.graphQLProblems
+	}
+
+	if meta.GraphQLStrings != nil {
+		return nil
+	}
+
+	names := meta.getGraphQLStrings()
+	problems := make(map[T]error)
+	users := make(map[string][]T, len(names))
+	for v, name := range names {
+		if !IsGraphQLName(name) {
+			problems[v] = fmt.Errorf("%w: %q for %#v", ErrInvalidGraphQLName, name, v)
+			continue
+		}
+
+		key := meta.Parsing.key(name)
+		users[key] = append(users[key], v)
+	}
+
+	for _, values := range users {
+		if len(values) < 2 {
+			continue
+		}
+		for _, v := range values {
+			problems[v] = fmt.Errorf("%w: %q for %#v", ErrDuplicateGraphQLString, names[v], v)
+		}
+	}
+
+	return problems
+}
+
+// IsGraphQLName checks if a name is a valid GraphQL enum value: a GraphQL
+// name which is not true, false or null.
+func IsGraphQLName(name string) bool {
+	switch name {
+	case "", "true", "false", "null":
+		return false
+	}
+
+	for i, r := range name {
+		letter := r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r == '_'
+		if !letter && (i == 0 || r < '0' || r > '9') {
+			return false
+		}
+	}
+
+	return true
+}
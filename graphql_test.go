@@ -0,0 +1,173 @@
+package goconstants_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/samonzeweb/goconstants"
+)
+
+func TestGraphQLHelpers(t *testing.T) {
+	meta := cstMeta
+	for _, compiled := range []bool{false, true} {
+		if compiled {
+			meta = meta.Compile()
+		}
+
+		name, err := meta.GraphQLNameHelper(bart)
+		if err != nil || name != "BART_SIMPSON" {
+			t.Errorf("expected BART_SIMPSON, got %s (%v, compiled: %t)", name, err, compiled)
+		}
+
+		value, err := meta.FromGraphQLNameHelper("LISA_SIMPSON")
+		if err != nil || value != lisa {
+			t.Errorf("expected %v, got %v (%v, compiled: %t)", lisa, value, err, compiled)
+		}
+
+		if _, err := meta.FromGraphQLNameHelper("lisa_simpson"); !errors.Is(err, goconstants.ErrUnknownValue) {
+			t.Errorf("expected error %v, got %v (compiled: %t)", goconstants.ErrUnknownValue, err, compiled)
+		}
+	}
+}
+
+func TestGraphQLStrings(t *testing.T) {
+	meta := cstMeta
+	meta.GraphQLStrings = map[simpson]string{homer: "HOMER", marge: "MARGE", bart: "BART", lisa: "LISA", maggie: "MAGGIE"}
+	if err := meta.Validate(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	var buf bytes.Buffer
+	meta.MarshalGQLHelper(homer, &buf)
+	if buf.String() != `"HOMER"` {
+		t.Errorf(`expected "HOMER", got %s`, buf.String())
+	}
+
+	buf.Reset()
+	meta.MarshalGQLHelper(999, &buf)
+	if buf.String() != "null" {
+		t.Errorf("expected null for unknown values, got %s", buf.String())
+	}
+
+	var value simpson
+	if err := meta.UnmarshalGQLHelper("MAGGIE", &value); err != nil || value != maggie {
+		t.Errorf("expected %v, got %v (%v)", maggie, value, err)
+	}
+
+	var decodeErr *goconstants.DecodeError
+	if err := meta.UnmarshalGQLHelper(42, &value); !errors.As(err, &decodeErr) {
+		t.Errorf("expected a DecodeError, got %v", err)
+	}
+}
+
+func TestValidateGraphQLStrings(t *testing.T) {
+	testCases := []struct {
+		name          string
+		strings       map[simpson]string
+		expectedError error
+	}{
+		{
+			name:          "missing value",
+			strings:       map[simpson]string{homer: "HOMER"},
+			expectedError: goconstants.ErrGraphQLStringsIncoherence,
+		},
+		{
+			name:          "invalid name",
+			strings:       map[simpson]string{homer: "HOMER-J", marge: "MARGE", bart: "BART", lisa: "LISA", maggie: "MAGGIE"},
+			expectedError: goconstants.ErrInvalidGraphQLName,
+		},
+		{
+			name:          "duplicate name",
+			strings:       map[simpson]string{homer: "HOMER", marge: "HOMER", bart: "BART", lisa: "LISA", maggie: "MAGGIE"},
+			expectedError: goconstants.ErrDuplicateGraphQLString,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			meta := cstMeta
+			meta.GraphQLStrings = testCase.strings
+
+			if err := meta.Validate(); !errors.Is(err, testCase.expectedError) {
+				t.Errorf("expected error %v, got %v", testCase.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestDerivedGraphQLNames(t *testing.T) {
+	type state int
+	testCases := []struct {
+		name          string
+		jsonStrings   map[state]string
+		graphQLName   string
+		expectedName  string
+		expectedError error
+	}{
+		{name: "digit", jsonStrings: map[state]string{1: "2nd", 2: "third"}, graphQLName: "_2ND", expectedName: "_2ND"},
+		{name: "transliterated", jsonStrings: map[state]string{1: "né", 2: "third"}, graphQLName: "NE", expectedName: "NE"},
+		{
+			name:          "not transliterable",
+			jsonStrings:   map[state]string{1: "東京", 2: "third"},
+			graphQLName:   "東京",
+			expectedError: goconstants.ErrInvalidGraphQLName,
+		},
+		{
+			name:          "invalid",
+			jsonStrings:   map[state]string{1: "+", 2: "third"},
+			graphQLName:   "+",
+			expectedError: goconstants.ErrInvalidGraphQLName,
+		},
+		{
+			name:          "duplicate",
+			jsonStrings:   map[state]string{1: "coding-hard", 2: "coding_hard"},
+			graphQLName:   "CODING_HARD",
+			expectedError: goconstants.ErrDuplicateGraphQLString,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			meta := goconstants.Metadata[state]{Name: "state", JSONStrings: testCase.jsonStrings}
+
+			// Only GraphQLStrings is validated.
+			if err := meta.Validate(); err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			for _, meta := range []goconstants.Metadata[state]{meta, meta.Compile()} {
+				name, err := meta.GraphQLNameHelper(1)
+				if !errors.Is(err, testCase.expectedError) || name != testCase.expectedName {
+					t.Errorf("expected %s (%v), got %s (%v)", testCase.expectedName, testCase.expectedError, name, err)
+				}
+
+				value, err := meta.FromGraphQLNameHelper(testCase.graphQLName)
+				if !errors.Is(err, testCase.expectedError) || (err == nil && value != 1) {
+					t.Errorf("expected 1 (%v), got %v (%v)", testCase.expectedError, value, err)
+				}
+			}
+		})
+	}
+}
+
+func TestIsGraphQLName(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected bool
+	}{
+		{input: "ASLEEP", expected: true},
+		{input: "_private2", expected: true},
+		{input: "2ND", expected: false},
+		{input: "joking-about-js", expected: false},
+		{input: "true", expected: false},
+		{input: "null", expected: false},
+		{input: "", expected: false},
+	}
+
+	for _, testCase := range testCases {
+		if valid := goconstants.IsGraphQLName(testCase.input); valid != testCase.expected {
+			t.Errorf("expected %t for %q, got %t", testCase.expected, testCase.input, valid)
+		}
+	}
+}
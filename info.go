@@ -0,0 +1,132 @@
+package goconstants
+
+import "reflect"
+
+// Info describes a constant type without its type parameter, allowing tools
+// (exporters, handlers, ...) to work with any constant type.
+type Info struct {
+	// Name is the name of the constant type.
+	Name string
+	// Type is the constant type.
+	Type reflect.Type
+	// Values describes the known values, in the order of Values.
+	Values []ValueInfo
+	// ProtoUnspecified is the Protocol Buffers name of the unspecified value
+	// numbered 0, blank if ProtoNumbers is not set or if 0 is the number of a
+	// known value.
+	ProtoUnspecified string
+	// Flags is true if the values are flags, combined in a single value (see
+	// Metadata.Flags). A combination is then represented in JSON as an array
+	// of JSON strings.
+	Flags bool
+}
+
+// ValueInfo describes a known value of a constant type.
+type ValueInfo struct {
+	// Value is the constant value.
+	Value any
+	// String, JSONString and DBString are the representations of the value.
+	// DBString is blank if DBStrings is not set.
+	String     string
+	JSONString string
+	DBString   string
+	// DBValue is the value stored in databases by ValueHelper, a string or
+	// an int64, or nil if the value can't be stored.
+	DBValue any
+	// ProtoName and ProtoNumber identify the value in Protocol Buffers, they
+	// are blank if ProtoNumbers is not set.
+	ProtoName   string
+	ProtoNumber int32
+	// GraphQLName is the GraphQL enum value.
+	GraphQLName string
+	// Deprecated is true if the value is deprecated, with an optional
+	// message and an optional replacement (nil if there is none).
+	Deprecated         bool
+	DeprecationMessage string
+	Replacement        any
+}
+
+// Info returns the description of the constant type.
+func (meta Metadata[T]) Info() Info {
+	values := meta.getValues()
+	info := Info{
+		Name:   meta.Name,
+		Type:   reflect.TypeFor[T](),
+		Values: make([]ValueInfo, 0, len(values)),
+		Flags:  meta.Flags,
+	}
+
+	strings := meta.getStrings()
+	jsonStrings := meta.getJSONStrings()
+	protoNames := meta.getProtoNames()
+	graphQLNames := meta.getGraphQLStrings()
+	if meta.ProtoNumbers != nil {
+		info.ProtoUnspecified = meta.protoUnspecified()
+	}
+	for _, v := range values {
+		value := ValueInfo{
+			Value:       v,
+			String:      strings[v],
+			JSONString:  jsonStrings[v],
+			DBString:    meta.DBStrings[v],
+			ProtoName:   protoNames[v],
+			ProtoNumber: meta.ProtoNumbers[v],
+			GraphQLName: graphQLNames[v],
+		}
+
+		if meta.DBStrings != nil {
+			value.DBValue = value.DBString
+		} else if n, ok := toInt64(v); ok {
+			value.DBValue = n
+		}
+
+		if deprecation, ok := meta.Deprecations[v]; ok {
+			value.Deprecated = true
+			value.DeprecationMessage = deprecation.Message
+			if deprecation.HasReplacement {
+				value.Replacement = deprecation.Replacement
+			}
+		}
+
+		info.Values = append(info.Values, value)
+	}
+
+	return info
+}
+
+// ReplacementOf returns the description of the replacement of a deprecated
+// value, and a boolean indicating if there is one.
+func (info Info) ReplacementOf(value ValueInfo) (ValueInfo, bool) {
+	if value.Replacement == nil {
+		return ValueInfo{}, false
+	}
+
+	for _, v := range info.Values {
+		if v.Value == value.Replacement {
+			return v, true
+		}
+	}
+
+	return ValueInfo{}, false
+}
+
+// DeprecationNote returns the deprecation message of a value, followed by
+// its replacement named with the given function, ie "not a state, use
+// coding". It's empty if there is neither message nor replacement.
+func (info Info) DeprecationNote(value ValueInfo, name func(ValueInfo) string) string {
+	note := value.DeprecationMessage
+	if replacement, ok := info.ReplacementOf(value); ok {
+		if note != "" {
+			note += ", "
+		}
+		note += "use " + name(replacement)
+	}
+
+	return note
+}
+
+// clone returns a copy of the Info which does not share its values.
+func (info Info) clone() Info {
+	info.Values = append([]ValueInfo{}, info.Values...)
+	return info
+}
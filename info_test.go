@@ -0,0 +1,114 @@
+package goconstants_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/samonzeweb/goconstants"
+)
+
+func TestInfo(t *testing.T) {
+	meta := cstMeta
+	meta.Order = []simpson{maggie, lisa, bart, marge, homer}
+	meta.DBStrings = map[simpson]string{homer: "h", marge: "m", bart: "b", lisa: "l", maggie: "mg"}
+	meta.Deprecations = map[simpson]goconstants.Deprecation[simpson]{
+		maggie: {Message: "too young", Replacement: lisa, HasReplacement: true},
+		bart:   {},
+	}
+
+	info := meta.Info()
+	if info.Name != "cst" || info.Type != reflect.TypeOf(simpson(0)) {
+		t.Errorf("unexpected name or type %s %v", info.Name, info.Type)
+	}
+
+	expected := []goconstants.ValueInfo{
+		{
+			Value: simpson(maggie), String: "Maggie Simpson", JSONString: "maggie_simpson",
+			DBString: "mg", DBValue: "mg", GraphQLName: "MAGGIE_SIMPSON",
+			Deprecated: true, DeprecationMessage: "too young", Replacement: simpson(lisa),
+		},
+		{
+			Value: simpson(lisa), String: "Lisa Simpson", JSONString: "lisa_simpson",
+			DBString: "l", DBValue: "l", GraphQLName: "LISA_SIMPSON",
+		},
+		{
+			Value: simpson(bart), String: "Bart Simpson", JSONString: "bart_simpson",
+			DBString: "b", DBValue: "b", GraphQLName: "BART_SIMPSON",
+			Deprecated: true,
+		},
+		{
+			Value: simpson(marge), String: "Marge Simpson", JSONString: "marge_simpson",
+			DBString: "m", DBValue: "m", GraphQLName: "MARGE_SIMPSON",
+		},
+		{
+			Value: simpson(homer), String: "Homer Simpson", JSONString: "homer_simpson",
+			DBString: "h", DBValue: "h", GraphQLName: "HOMER_SIMPSON",
+		},
+	}
+	if !reflect.DeepEqual(info.Values, expected) {
+		t.Errorf("expected %v, got %v", expected, info.Values)
+	}
+
+	// Without DBStrings, the values are stored as integers.
+	if dbValue := cstMeta.Info().Values[0].DBValue; dbValue != int64(homer) {
+		t.Errorf("expected %d, got %#v", homer, dbValue)
+	}
+}
+
+func TestReplacementOf(t *testing.T) {
+	meta := cstMeta
+	meta.Deprecations = map[simpson]goconstants.Deprecation[simpson]{
+		maggie: {Replacement: lisa, HasReplacement: true},
+		bart:   {},
+	}
+	info := meta.Info()
+
+	testCases := []struct {
+		input    simpson
+		expected string
+		ok       bool
+	}{
+		{input: maggie, expected: "lisa_simpson", ok: true},
+		{input: bart, ok: false},
+		{input: homer, ok: false},
+	}
+
+	for _, testCase := range testCases {
+		i, _ := meta.Index(testCase.input)
+		replacement, ok := info.ReplacementOf(info.Values[i])
+		if ok != testCase.ok || replacement.JSONString != testCase.expected {
+			t.Errorf("expected %q (%t), got %q (%t)", testCase.expected, testCase.ok, replacement.JSONString, ok)
+		}
+	}
+}
+
+func TestDeprecationNote(t *testing.T) {
+	meta := cstMeta
+	meta.Deprecations = map[simpson]goconstants.Deprecation[simpson]{
+		homer:  {Message: "too old", Replacement: bart, HasReplacement: true},
+		maggie: {Replacement: lisa, HasReplacement: true},
+		marge:  {Message: "too kind"},
+		bart:   {},
+	}
+	info := meta.Info()
+
+	testCases := []struct {
+		input    simpson
+		expected string
+	}{
+		{input: homer, expected: "too old, use Bart Simpson"},
+		{input: maggie, expected: "use Lisa Simpson"},
+		{input: marge, expected: "too kind"},
+		{input: bart, expected: ""},
+	}
+
+	for _, testCase := range testCases {
+		i, _ := meta.Index(testCase.input)
+		note := info.DeprecationNote(info.Values[i], func(replacement goconstants.ValueInfo) string {
+			return replacement.String
+		})
+		if note != testCase.expected {
+			t.Errorf("expected %q, got %q", testCase.expected, note)
+		}
+	}
+}
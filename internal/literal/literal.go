@@ -0,0 +1,20 @@
+// Package literal writes the string literals of the exporters generating
+// source code (TypeScript, GraphQL, ...).
+package literal
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// Quote returns a string literal, as a JSON string without HTML escaping.
+// It's valid in TypeScript and GraphQL.
+func Quote(s string) string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(s)
+
+	return strings.TrimSuffix(buf.String(), "\n")
+}
@@ -0,0 +1,24 @@
+package literal
+
+import "testing"
+
+func TestQuote(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{input: "asleep", expected: `"asleep"`},
+		{input: `say "hi"`, expected: `"say \"hi\""`},
+		{input: "a\nb", expected: `"a\nb"`},
+		{input: "<b>&</b>", expected: `"<b>&</b>"`},
+		{input: "Café", expected: `"Café"`},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			if quoted := Quote(testCase.input); quoted != testCase.expected {
+				t.Errorf("expected %s, got %s", testCase.expected, quoted)
+			}
+		})
+	}
+}
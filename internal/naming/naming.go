@@ -0,0 +1,168 @@
+// Package naming derives identifiers from the representations of constant
+// values, for the exporters needing them (OpenAPI, Protocol Buffers,
+// GraphQL, ...).
+package naming
+
+import (
+	"strings"
+	"unicode"
+)
+
+// transliterations contains the ASCII letters replacing the Latin letters
+// with diacritics in identifiers.
+var transliterations = func() map[rune]string {
+	groups := []struct {
+		letters string
+		ascii   string
+	}{
+		{"ÀÁÂÃÄÅĀĂĄ", "A"}, {"àáâãäåāăą", "a"},
+		{"ÇĆĈĊČ", "C"}, {"çćĉċč", "c"},
+		{"ĎĐ", "D"}, {"ďđ", "d"},
+		{"ÈÉÊËĒĔĖĘĚ", "E"}, {"èéêëēĕėęě", "e"},
+		{"ĜĞĠĢ", "G"}, {"ĝğġģ", "g"},
+		{"ĤĦ", "H"}, {"ĥħ", "h"},
+		{"ÌÍÎÏĨĪĬĮİ", "I"}, {"ìíîïĩīĭįı", "i"},
+		{"Ĵ", "J"}, {"ĵ", "j"},
+		{"Ķ", "K"}, {"ķ", "k"},
+		{"ĹĻĽĿŁ", "L"}, {"ĺļľŀł", "l"},
+		{"ÑŃŅŇ", "N"}, {"ñńņň", "n"},
+		{"ÒÓÔÕÖØŌŎŐ", "O"}, {"òóôõöøōŏő", "o"},
+		{"ŔŖŘ", "R"}, {"ŕŗř", "r"},
+		{"ŚŜŞŠ", "S"}, {"śŝşš", "s"},
+		{"ŢŤŦ", "T"}, {"ţťŧ", "t"},
+		{"ÙÚÛÜŨŪŬŮŰŲ", "U"}, {"ùúûüũūŭůűų", "u"},
+		{"Ŵ", "W"}, {"ŵ", "w"},
+		{"ÝŶŸ", "Y"}, {"ýÿŷ", "y"},
+		{"ŹŻŽ", "Z"}, {"źżž", "z"},
+		{"Æ", "AE"}, {"æ", "ae"},
+		{"Œ", "OE"}, {"œ", "oe"},
+		{"ß", "ss"},
+	}
+
+	transliterations := make(map[rune]string)
+	for _, group := range groups {
+		for _, letter := range group.letters {
+			transliterations[letter] = group.ascii
+		}
+	}
+
+	return transliterations
+}()
+
+// Words splits a representation into words, using characters which are not
+// letters or digits, and case changes, as boundaries. Acronyms are kept as a
+// single word, ie "JSON parser-v2" gives "JSON", "parser" and "v2", and
+// letters with diacritics are kept, ie "Café crème" gives "Café" and "crème".
+func Words(s string) []string {
+	var words []string
+	runes := []rune(s)
+	start := 0
+
+	for i := 0; i <= len(runes); i++ {
+		switch {
+		case i == len(runes):
+		case !isAlphanumeric(runes[i]):
+		case i > start && isBoundary(runes, i):
+		default:
+			continue
+		}
+
+		if i > start {
+			words = append(words, string(runes[start:i]))
+		}
+
+		start = i
+		if i < len(runes) && !isAlphanumeric(runes[i]) {
+			start = i + 1
+		}
+	}
+
+	return words
+}
+
+// Pascal returns the representation in PascalCase, ie "HomerSimpson", as an
+// ASCII identifier. The boolean is false if the representation can't be
+// represented (see ASCIIWords).
+func Pascal(s string) (string, bool) {
+	words, ok := ASCIIWords(s)
+	if !ok {
+		return "", false
+	}
+
+	var b strings.Builder
+	for _, word := range words {
+		b.WriteString(strings.ToUpper(word[:1]))
+		b.WriteString(strings.ToLower(word[1:]))
+	}
+
+	return identifier(b.String()), true
+}
+
+// ScreamingSnake returns the representation in SCREAMING_SNAKE_CASE, ie
+// "HOMER_SIMPSON", as an ASCII identifier. The boolean is false if the
+// representation can't be represented (see ASCIIWords).
+func ScreamingSnake(s string) (string, bool) {
+	words, ok := ASCIIWords(s)
+	if !ok {
+		return "", false
+	}
+
+	return identifier(strings.ToUpper(strings.Join(words, "_"))), true
+}
+
+// ASCIIWords returns the words of a representation, the letters with
+// diacritics being transliterated, ie "Café crème" gives "Cafe" and "creme".
+// The boolean is false if the representation has no words, or if a letter
+// can't be transliterated (ie "東京").
+func ASCIIWords(s string) ([]string, bool) {
+	words := Words(s)
+	for i, word := range words {
+		var b strings.Builder
+		for _, r := range word {
+			switch ascii, ok := transliterations[r]; {
+			case r < unicode.MaxASCII:
+				b.WriteRune(r)
+			case ok:
+				b.WriteString(ascii)
+			default:
+				return nil, false
+			}
+		}
+		words[i] = b.String()
+	}
+
+	return words, len(words) > 0
+}
+
+// identifier prefixes the name with an underscore if it starts with a digit.
+func identifier(name string) string {
+	if unicode.IsDigit(rune(name[0])) {
+		return "_" + name
+	}
+
+	return name
+}
+
+// isAlphanumeric checks if a rune is a letter or a digit.
+func isAlphanumeric(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// isBoundary checks if a new word begins at position i.
+func isBoundary(runes []rune, i int) bool {
+	previous, current := runes[i-1], runes[i]
+	if !isAlphanumeric(previous) {
+		return false
+	}
+
+	if !unicode.IsUpper(current) {
+		return false
+	}
+
+	if !unicode.IsUpper(previous) {
+		return true
+	}
+
+	// Last letter of an acronym followed by a word, ie the P in "JSONParser".
+	return i+1 < len(runes) && unicode.IsLower(runes[i+1])
+}
@@ -0,0 +1,65 @@
+package naming
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWords(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected []string
+	}{
+		{input: "asleep", expected: []string{"asleep"}},
+		{input: "joking_about_js", expected: []string{"joking", "about", "js"}},
+		{input: "JokingAboutJS", expected: []string{"Joking", "About", "JS"}},
+		{input: "JSON parser-v2", expected: []string{"JSON", "parser", "v2"}},
+		{input: "http2Server", expected: []string{"http2", "Server"}},
+		{input: "Café crème", expected: []string{"Café", "crème"}},
+		{input: "東京 tower", expected: []string{"東京", "tower"}},
+		{input: " -- ", expected: nil},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			words := Words(testCase.input)
+
+			if !reflect.DeepEqual(words, testCase.expected) {
+				t.Errorf("expected %#v, got %#v", testCase.expected, words)
+			}
+		})
+	}
+}
+
+func TestIdentifiers(t *testing.T) {
+	testCases := []struct {
+		input                  string
+		expectedPascal         string
+		expectedScreamingSnake string
+		expectedOk             bool
+	}{
+		{input: "homer_simpson", expectedPascal: "HomerSimpson", expectedScreamingSnake: "HOMER_SIMPSON", expectedOk: true},
+		{input: "Coding hard", expectedPascal: "CodingHard", expectedScreamingSnake: "CODING_HARD", expectedOk: true},
+		{input: "JokingAboutJS", expectedPascal: "JokingAboutJs", expectedScreamingSnake: "JOKING_ABOUT_JS", expectedOk: true},
+		{input: "2nd", expectedPascal: "_2nd", expectedScreamingSnake: "_2ND", expectedOk: true},
+		{input: "Café crème", expectedPascal: "CafeCreme", expectedScreamingSnake: "CAFE_CREME", expectedOk: true},
+		{input: "Straße", expectedPascal: "Strasse", expectedScreamingSnake: "STRASSE", expectedOk: true},
+		{input: "東京", expectedOk: false},
+		{input: " -- ", expectedOk: false},
+		{input: "", expectedOk: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			pascal, ok := Pascal(testCase.input)
+			if ok != testCase.expectedOk || pascal != testCase.expectedPascal {
+				t.Errorf("expected %q (%t), got %q (%t)", testCase.expectedPascal, testCase.expectedOk, pascal, ok)
+			}
+
+			screaming, ok := ScreamingSnake(testCase.input)
+			if ok != testCase.expectedOk || screaming != testCase.expectedScreamingSnake {
+				t.Errorf("expected %q (%t), got %q (%t)", testCase.expectedScreamingSnake, testCase.expectedOk, screaming, ok)
+			}
+		})
+	}
+}
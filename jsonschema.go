@@ -0,0 +1,119 @@
+package goconstants
+
+// JSONSchemaDraft is the JSON Schema dialect of the generated schemas.
+const JSONSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchema is a JSON Schema (draft 2020-12) fragment describing the JSON
+// representation of a constant type. Use encoding/json to get its content.
+//
+// The schema only contains the JSON strings produced when marshalling. The
+// JSONAliases, and the representations matched thanks to the parse options,
+// are accepted by UnmarshalJSONHelper but are not part of the schema, so
+// that clients only send the canonical strings.
+//
+// The schema of flags (see Metadata.Flags) is an array whose items are the
+// JSON strings of the flags.
+type JSONSchema struct {
+	Title       string            `json:"title,omitempty"`
+	Type        string            `json:"type"`
+	Enum        []string          `json:"enum,omitempty"`
+	OneOf       []JSONSchemaValue `json:"oneOf,omitempty"`
+	Items       *JSONSchema       `json:"items,omitempty"`
+	UniqueItems bool              `json:"uniqueItems,omitempty"`
+}
+
+// JSONSchemaValue is the JSON Schema of one value of a constant type.
+// The description is the string of the value, and deprecated values have
+// their deprecation message and replacement in the comment.
+type JSONSchemaValue struct {
+	Const       string `json:"const"`
+	Description string `json:"description,omitempty"`
+	Deprecated  bool   `json:"deprecated,omitempty"`
+	Comment     string `json:"$comment,omitempty"`
+}
+
+// JSONSchemaDocument is a JSON Schema document defining several constant
+// types in $defs, keyed by name. Reference them with "#/$defs/<name>".
+type JSONSchemaDocument struct {
+	Schema string                `json:"$schema"`
+	Defs   map[string]JSONSchema `json:"$defs"`
+}
+
+// JSONSchemaHelper returns the JSON Schema of the JSON representation of the
+// constant type.
+func (meta Metadata[T]) JSONSchemaHelper() JSONSchema {
+	return meta.Info().JSONSchema()
+}
+
+// JSONSchema returns the JSON Schema of the JSON representation of the
+// constant type.
+func (info Info) JSONSchema() JSONSchema {
+	if info.Flags {
+		items := info.stringsSchema()
+		return JSONSchema{Title: info.Name, Type: "array", Items: &items, UniqueItems: true}
+	}
+
+	schema := info.stringsSchema()
+	schema.Title = info.Name
+
+	return schema
+}
+
+// stringsSchema returns the JSON Schema of the JSON strings of the values.
+func (info Info) stringsSchema() JSONSchema {
+	schema := JSONSchema{
+		Type:  "string",
+		Enum:  make([]string, 0, len(info.Values)),
+		OneOf: make([]JSONSchemaValue, 0, len(info.Values)),
+	}
+
+	for _, value := range info.Values {
+		schema.Enum = append(schema.Enum, value.JSONString)
+
+		valueSchema := JSONSchemaValue{
+			Const:       value.JSONString,
+			Description: value.String,
+			Deprecated:  value.Deprecated,
+		}
+		if value.Deprecated {
+			valueSchema.Comment = info.deprecationComment(value)
+		}
+		schema.OneOf = append(schema.OneOf, valueSchema)
+	}
+
+	return schema
+}
+
+// deprecationComment returns the deprecation note of a value, its
+// replacement being named by its JSON string.
+func (info Info) deprecationComment(value ValueInfo) string {
+	note := info.DeprecationNote(value, func(replacement ValueInfo) string {
+		return replacement.JSONString
+	})
+	if note == "" {
+		return "deprecated"
+	}
+
+	return "deprecated: " + note
+}
+
+// NewJSONSchemaDocument returns a JSON Schema document defining the given
+// constant types.
+func NewJSONSchemaDocument(infos ...Info) JSONSchemaDocument {
+	document := JSONSchemaDocument{
+		Schema: JSONSchemaDraft,
+		Defs:   make(map[string]JSONSchema, len(infos)),
+	}
+
+	for _, info := range infos {
+		document.Defs[info.Name] = info.JSONSchema()
+	}
+
+	return document
+}
+
+// RegisteredJSONSchema returns a JSON Schema document defining all
+// registered constant types (see Register).
+func RegisteredJSONSchema() JSONSchemaDocument {
+	return NewJSONSchemaDocument(Registered()...)
+}
@@ -0,0 +1,86 @@
+package goconstants_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/samonzeweb/goconstants"
+)
+
+type schemaRegistered int
+
+func TestJSONSchemaHelper(t *testing.T) {
+	meta := cstMeta
+	meta.Order = []simpson{homer, marge, bart, lisa, maggie}
+	meta.Deprecations = map[simpson]goconstants.Deprecation[simpson]{
+		maggie: {Message: "too young", Replacement: lisa, HasReplacement: true},
+		bart:   {},
+	}
+
+	b, err := json.Marshal(meta.JSONSchemaHelper())
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	expected := `{"title":"cst","type":"string",` +
+		`"enum":["homer_simpson","marge_simpson","bart_simpson","lisa_simpson","maggie_simpson"],` +
+		`"oneOf":[` +
+		`{"const":"homer_simpson","description":"Homer Simpson"},` +
+		`{"const":"marge_simpson","description":"Marge Simpson"},` +
+		`{"const":"bart_simpson","description":"Bart Simpson","deprecated":true,"$comment":"deprecated"},` +
+		`{"const":"lisa_simpson","description":"Lisa Simpson"},` +
+		`{"const":"maggie_simpson","description":"Maggie Simpson","deprecated":true,` +
+		`"$comment":"deprecated: too young, use lisa_simpson"}]}`
+	if string(b) != expected {
+		t.Errorf("expected %s, got %s", expected, string(b))
+	}
+}
+
+func TestJSONSchemaFlags(t *testing.T) {
+	b, err := json.Marshal(permissionMeta.JSONSchemaHelper())
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	expected := `{"title":"permission","type":"array","items":{"type":"string",` +
+		`"enum":["read","write","exec"],` +
+		`"oneOf":[{"const":"read","description":"Read"},{"const":"write","description":"Write"},` +
+		`{"const":"exec","description":"Exec"}]},"uniqueItems":true}`
+	if string(b) != expected {
+		t.Errorf("expected %s, got %s", expected, string(b))
+	}
+}
+
+func TestRegisteredJSONSchema(t *testing.T) {
+	goconstants.IsolateRegistry(t)
+	goconstants.MustRegister(goconstants.Metadata[schemaRegistered]{
+		Name:        "schemaRegistered",
+		Strings:     map[schemaRegistered]string{1: "One", 2: "Two"},
+		JSONStrings: map[schemaRegistered]string{1: "one", 2: "two"},
+	})
+
+	b, err := json.Marshal(goconstants.NewJSONSchemaDocument(cstMeta.Info()))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	var document struct {
+		Schema string                    `json:"$schema"`
+		Defs   map[string]map[string]any `json:"$defs"`
+	}
+	if err := json.Unmarshal(b, &document); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if document.Schema != goconstants.JSONSchemaDraft || len(document.Defs) != 1 || document.Defs["cst"]["title"] != "cst" {
+		t.Errorf("unexpected document %s", string(b))
+	}
+
+	registered := goconstants.RegisteredJSONSchema()
+	schema, ok := registered.Defs["schemaRegistered"]
+	if !ok || len(registered.Defs) != 1 {
+		t.Fatalf("expected only schemaRegistered, got %v", registered.Defs)
+	}
+	if len(schema.Enum) != 2 || schema.Enum[0] != "one" || schema.Enum[1] != "two" {
+		t.Errorf("expected [one two], got %v", schema.Enum)
+	}
+}
@@ -4,11 +4,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 )
 
 // Metadata constains metadata of a typed constant.
 // Always restrict visibility of variables of Metadata type as
-// they are not imutable.
+// they are not imutable. Use New to get a read-only Descriptor.
 //
 // Strings and JSONStrings allows to separated user facing and encoding strings,
 // at least one of them must be set.
@@ -25,40 +27,190 @@ type Metadata[T comparable] struct {
 	// All valid valid must be present in the map.
 	// If not set, the content of Strings will be used.
 	JSONStrings map[T]string
+	// DBStrings allow the mapping between a constant value and its database
+	// representation, used by ValueHelper and ScanHelper.
+	// If set, all valid values must be present in the map.
+	// If not set, the values are stored as integers.
+	DBStrings map[T]string
+	// Text is the representation used by MarshalTextHelper and
+	// UnmarshalTextHelper. The JSON representation is used by default.
+	Text Representation
+	// Order is the order of the values returned by Values, usually the
+	// declaration order. If set, it must contain each known value once.
+	Order []T
+	// Parsing configures how representations are matched when parsing,
+	// an exact match is required by default.
+	Parsing ParseOptions
+	// Aliases are other strings accepted when parsing strings, like legacy
+	// spellings. They are never used to convert a value to a string.
+	// If Strings is not set, JSONAliases are used instead.
+	Aliases map[T][]string
+	// JSONAliases are other strings accepted when parsing JSON (or text
+	// using the JSON representation). They are never used when marshalling.
+	// If JSONStrings is not set, Aliases are used instead.
+	JSONAliases map[T][]string
+	// OnAlias, if set, is called each time an alias is parsed, ie to track
+	// the usage of legacy spellings.
+	OnAlias func(v T, alias string)
+	// ListAccepted is the maximal number of values for which parse errors
+	// list all accepted representations (see UnknownValueError.Accepted).
+	// Zero disables the list.
+	ListAccepted int
+	// Deprecations contains the deprecated values. Deprecated values are
+	// still valid, MarshalDeprecated and ParseDeprecated select what happens
+	// when they are marshalled (JSON, text, database) or parsed.
+	Deprecations      map[T]Deprecation[T]
+	MarshalDeprecated DeprecationPolicy
+	ParseDeprecated   DeprecationPolicy
+	// OnDeprecated is called when a deprecated value is marshalled or parsed
+	// with the NotifyDeprecated policy.
+	OnDeprecated func(v T, deprecation Deprecation[T])
+	// ProtoNumbers maps the values to their Protocol Buffers numbers, used by
+	// the ProtoNumberHelper and ProtoNameHelper helpers. If set, all valid
+	// values must be present in the map.
+	// The number 0 should be reserved to the unspecified value, named
+	// <ProtoPrefix>_UNSPECIFIED, unless the zero value is a known value.
+	ProtoNumbers map[T]int32
+	// ProtoNames maps the values to their Protocol Buffers names.
+	// If not set, the names are the JSON strings in SCREAMING_SNAKE_CASE,
+	// prefixed by ProtoPrefix (ie GOPHER_STATE_ASLEEP).
+	ProtoNames map[T]string
+	// ProtoPrefix prefixes the Protocol Buffers names. The Name in
+	// SCREAMING_SNAKE_CASE is used by default.
+	ProtoPrefix string
+	// GraphQLStrings allow the mapping between a constant value and its
+	// GraphQL enum value. If set, all valid values must be present in the map.
+	// If not set, the JSON strings in SCREAMING_SNAKE_CASE are used, and
+	// GraphQLNameHelper reports the ones which are not valid or distinct
+	// GraphQL names (ie "coding-hard" and "coding_hard").
+	GraphQLStrings map[T]string
+	// Flags allows combinations of values, for integer types whose values
+	// are single bits (the zero value could also be known). A combination is
+	// valid if all its bits are known values, and is represented by the
+	// strings of its values joined by FlagSeparator (ie "Read|Write"), or by
+	// a JSON array (ie ["read","write"]).
+	Flags bool
+
+	// index contains the reverse lookups built by Compile, nil otherwise.
+	index *index[T]
 }
 
 // Errors returned by Validate
 var (
-	ErrNameMissing        = errors.New("the Name field is blank")
-	ErrNoStringsDefined   = errors.New("neither Strings not JSONStrings are defined")
-	ErrStringsIncoherence = errors.New("Strings and JSONStrings does not have the same keys")
+	ErrNameMissing          = errors.New("the Name field is blank")
+	ErrNoStringsDefined     = errors.New("neither Strings not JSONStrings are defined")
+	ErrStringsIncoherence   = errors.New("Strings and JSONStrings does not have the same keys")
+	ErrDuplicateString      = errors.New("several values share the same string")
+	ErrDuplicateJSONString  = errors.New("several values share the same JSON string")
+	ErrDBStringsIncoherence = errors.New("DBStrings does not have the same keys than other strings")
+	ErrDuplicateDBString    = errors.New("several values share the same database string")
+	ErrTextUndefined        = errors.New("the representation used by Text has no strings")
 )
 
-// Validate checks that the Metadata instance is valid.
+// Validate checks that the Metadata instance is valid, and returns the first
+// problem found.
 // Use it preferably in a dedicated test.
 func (meta Metadata[T]) Validate() error {
+	problems := meta.problems()
+	if len(problems) == 0 {
+		return nil
+	}
+
+	return problems[0]
+}
+
+// Diagnose checks that the Metadata instance is valid like Validate, but
+// returns all problems found, joined with errors.Join.
+func (meta Metadata[T]) Diagnose() error {
+	return errors.Join(meta.problems()...)
+}
+
+// problems returns all problems of the Metadata instance.
+func (meta Metadata[T]) problems() []error {
+	var problems []error
+
 	if meta.Name == "" {
-		return ErrNameMissing
+		problems = append(problems, ErrNameMissing)
+	}
+
+	if len(meta.Strings) == 0 && len(meta.JSONStrings) == 0 {
+		return append(problems, ErrNoStringsDefined)
+	}
+
+	if meta.Strings != nil && meta.JSONStrings != nil && !sameKeys(meta.Strings, meta.JSONStrings) {
+		problems = append(problems, ErrStringsIncoherence)
+	}
+
+	if meta.DBStrings != nil && !sameKeys(meta.getStrings(), meta.DBStrings) {
+		problems = append(problems, ErrDBStringsIncoherence)
 	}
 
-	if (meta.Strings == nil || len(meta.Strings) == 0) &&
-		(meta.JSONStrings == nil || len(meta.JSONStrings) == 0) {
-		return ErrNoStringsDefined
+	if !meta.Text.isValid() {
+		problems = append(problems, fmt.Errorf("%w for Text: %d", ErrUnknownRepresentation, meta.Text))
+	} else if meta.getRepresentation(meta.Text) == nil {
+		problems = append(problems, fmt.Errorf("%w: %s", ErrTextUndefined, meta.Text))
 	}
 
-	if meta.Strings != nil && meta.JSONStrings != nil {
-		if len(meta.Strings) != len(meta.JSONStrings) {
-			return ErrStringsIncoherence
+	if !meta.Parsing.isValid() {
+		problems = append(problems, fmt.Errorf("%w: %d", ErrUnknownCaseFolding, meta.Parsing.CaseFolding))
+	}
+
+	problems = append(problems, meta.orderProblems()...)
+	problems = append(problems, meta.deprecationsProblems()...)
+	problems = append(problems, meta.protoProblems()...)
+	problems = append(problems, meta.graphQLProblems()...)
+	problems = append(problems, meta.flagsProblems()...)
+	problems = append(problems, meta.aliasesProblems("Aliases", meta.Aliases, StringRepresentation)...)
+	problems = append(problems, meta.aliasesProblems("JSONAliases", meta.JSONAliases, JSONRepresentation)...)
+	problems = append(problems, duplicates(meta.Strings, meta.Parsing, ErrDuplicateString)...)
+	problems = append(problems, duplicates(meta.JSONStrings, meta.Parsing, ErrDuplicateJSONString)...)
+	problems = append(problems, duplicates(meta.DBStrings, meta.Parsing, ErrDuplicateDBString)...)
+
+	return problems
+}
+
+// sameKeys checks that both maps have the same keys.
+func sameKeys[T comparable, A any, B any](a map[T]A, b map[T]B) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for k := range a {
+		if _, ok := b[k]; !ok {
+			return false
 		}
+	}
 
-		for k := range meta.Strings {
-			if _, ok := meta.JSONStrings[k]; !ok {
-				return ErrStringsIncoherence
-			}
+	return true
+}
+
+// duplicates returns an error wrapping sentinel for each representation
+// shared by several values, once transformed as specified by the parse
+// options. The errors are sorted by representation.
+func duplicates[T comparable](representations map[T]string, opts ParseOptions, sentinel error) []error {
+	users := make(map[string][]string, len(representations))
+	for k, v := range representations {
+		key := opts.key(v)
+		users[key] = append(users[key], fmt.Sprintf("%#v", k))
+	}
+
+	shared := make([]string, 0)
+	for representation, values := range users {
+		if len(values) > 1 {
+			shared = append(shared, representation)
 		}
 	}
+	sort.Strings(shared)
 
-	return nil
+	problems := make([]error, 0, len(shared))
+	for _, representation := range shared {
+		values := users[representation]
+		sort.Strings(values)
+		problems = append(problems, fmt.Errorf("%w: %q is used by %s",
+			sentinel, representation, strings.Join(values, ", ")))
+	}
+
+	return problems
 }
 
 // StringHelper returns a string representing the constant value.
@@ -72,10 +224,14 @@ func (meta Metadata[T]) StringHelper(v T) string {
 	return s
 }
 
-// ToStringHelper returns a string representing the constant value or an error
-// if the value is not known. Use it rather than StringHelper if you need to
-// check the validity of the value.
+// ToStringHelper returns a string representing the constant value or an
+// UnknownValueError if the value is not known. Use it rather than
+// StringHelper if you need to check the validity of the value.
 func (meta Metadata[T]) ToStringHelper(v T) (string, error) {
+	if meta.Flags {
+		return meta.formatFlags(v, StringRepresentation)
+	}
+
 	return meta.toStringHelper(v, meta.getStrings())
 }
 
@@ -83,24 +239,48 @@ func (meta Metadata[T]) ToStringHelper(v T) (string, error) {
 // boolean indicating if the value is valid.
 // If the boolean is false, ignore the returned value.
 func (meta Metadata[T]) FromStringHelper(representation string) (T, bool) {
-	return meta.fromStringHelper(representation, meta.getStrings())
+	if meta.Flags {
+		value, err := meta.decodeFlags(representation, StringRepresentation)
+		return value, err == nil
+	}
+
+	value, ok := meta.parse(representation, StringRepresentation)
+	if !ok {
+		return value, false
+	}
+
+	value, err := meta.parsed(value)
+	return value, err == nil
 }
 
-// toStringHelper returns a string representing the constant value or an error
-// if the value is not known.
+// toStringHelper returns a string representing the constant value or an
+// UnknownValueError if the value is not known.
 func (meta Metadata[T]) toStringHelper(v T, strings map[T]string) (string, error) {
 	if s, ok := strings[v]; ok {
 		return s, nil
 	}
 
-	return "", fmt.Errorf("invalid %s value: %#v", meta.Name, v)
+	return "", &UnknownValueError{Enum: meta.Name, Value: v}
 }
 
 // fromStringHelper converts a string to its associated constant value, and a
 // boolean indicating if the value is valid.
-func (meta Metadata[T]) fromStringHelper(representation string, strings map[T]string) (T, bool) {
+// The reverse map is used if available (see Compile), otherwise the strings
+// are scanned. The representations are compared as defined by Parsing.
+func (meta Metadata[T]) fromStringHelper(representation string, strings map[T]string, reverse map[string]T) (T, bool) {
+	key := meta.Parsing.key(representation)
+	if reverse != nil {
+		value, ok := reverse[key]
+		return value, ok
+	}
+
+	exact := meta.Parsing.isExact()
 	for k, v := range strings {
-		if representation == v {
+		if !exact {
+			v = meta.Parsing.key(v)
+		}
+
+		if key == v {
 			return k, true
 		}
 	}
@@ -109,13 +289,19 @@ func (meta Metadata[T]) fromStringHelper(representation string, strings map[T]st
 	return zero, false
 }
 
-// IsValidHelper checks if a given constant is valid (known).
+// IsValidHelper checks if a given constant is valid (known). With Flags, a
+// combination of known values is valid.
 func (meta Metadata[T]) IsValidHelper(v T) bool {
+	if meta.Flags {
+		_, err := meta.splitFlags(v)
+		return err == nil
+	}
+
 	_, ok := meta.getStrings()[v]
 	return ok
 }
 
-//getStrings returns Strings if defined (not nil) or JSONStrings
+// getStrings returns Strings if defined (not nil) or JSONStrings
 // as fallback.
 func (meta Metadata[T]) getStrings() map[T]string {
 	if meta.Strings != nil {
@@ -125,7 +311,7 @@ func (meta Metadata[T]) getStrings() map[T]string {
 	return meta.JSONStrings
 }
 
-//getJSONStrings returns JSONStrings if defined (not nil) or Strings
+// getJSONStrings returns JSONStrings if defined (not nil) or Strings
 // as fallback.
 func (meta Metadata[T]) getJSONStrings() map[T]string {
 	if meta.JSONStrings != nil {
@@ -135,9 +321,71 @@ func (meta Metadata[T]) getJSONStrings() map[T]string {
 	return meta.Strings
 }
 
+// clone returns a copy of the Metadata which does not share any map with
+// the original one. The copy is not compiled.
+func (meta Metadata[T]) clone() Metadata[T] {
+	meta.Strings = copyMap(meta.Strings)
+	meta.JSONStrings = copyMap(meta.JSONStrings)
+	meta.DBStrings = copyMap(meta.DBStrings)
+	if meta.Order != nil {
+		meta.Order = append([]T{}, meta.Order...)
+	}
+	meta.Aliases = copyAliases(meta.Aliases)
+	meta.JSONAliases = copyAliases(meta.JSONAliases)
+	meta.Deprecations = copyMap(meta.Deprecations)
+	meta.ProtoNumbers = copyMap(meta.ProtoNumbers)
+	meta.ProtoNames = copyMap(meta.ProtoNames)
+	meta.GraphQLStrings = copyMap(meta.GraphQLStrings)
+	meta.index = nil
+
+	return meta
+}
+
+// sortedKeys returns the keys of a map sorted by their Go representation,
+// allowing to report problems in a stable order.
+func sortedKeys[K comparable, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprintf("%#v", keys[i]) < fmt.Sprintf("%#v", keys[j])
+	})
+
+	return keys
+}
+
+// copyMap returns a copy of the given map, or nil if the map is nil.
+func copyMap[K comparable, V any](m map[K]V) map[K]V {
+	if m == nil {
+		return nil
+	}
+
+	c := make(map[K]V, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+
+	return c
+}
+
 // MarshalJSONHelper allows the implementation of MarshalJSON for
 // the associated constant type.
 func (meta Metadata[T]) MarshalJSONHelper(v T) ([]byte, error) {
+	v, err := meta.marshalled(v)
+	if err != nil {
+		return nil, fmt.Errorf("unable to mashal %s type to json: %w", meta.Name, err)
+	}
+
+	if meta.Flags {
+		b, err := meta.marshalFlagsJSON(v)
+		if err != nil {
+			return nil, fmt.Errorf("unable to mashal %s type to json: %w", meta.Name, err)
+		}
+
+		return b, nil
+	}
+
 	representation, err := meta.toStringHelper(v, meta.getJSONStrings())
 	if err != nil {
 		return nil, fmt.Errorf("unable to mashal %s type to json: %w", meta.Name, err)
@@ -148,16 +396,30 @@ func (meta Metadata[T]) MarshalJSONHelper(v T) ([]byte, error) {
 
 // UnmarshalJSONHelper allows the implementation of UnmarshalJSON for
 // the associated constant type.
+// It returns a DecodeError if the content is not a JSON string, an
+// UnknownValueError if the string is unknown, and a DeprecatedValueError if
+// the value is deprecated and rejected.
+// With Flags, the content could also be a JSON array of strings.
 func (meta Metadata[T]) UnmarshalJSONHelper(b []byte, v *T) error {
+	if meta.Flags {
+		value, err := meta.unmarshalFlagsJSON(b)
+		if err != nil {
+			return err
+		}
+
+		*v = value
+		return nil
+	}
+
 	var representation string
 	err := json.Unmarshal(b, &representation)
 	if err != nil {
-		return fmt.Errorf("unable to unmashal %s type from json: %w", meta.Name, err)
+		return &DecodeError{Enum: meta.Name, Format: "json", Err: err}
 	}
 
-	value, ok := meta.fromStringHelper(representation, meta.getJSONStrings())
-	if !ok {
-		return fmt.Errorf("unable to unmashal json, unknown value: %s", representation)
+	value, err := meta.decode(representation, JSONRepresentation)
+	if err != nil {
+		return err
 	}
 
 	*v = value
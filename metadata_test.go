@@ -1,6 +1,7 @@
 package goconstants_test
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 
@@ -66,6 +67,77 @@ func TestValidate(t *testing.T) {
 	}
 }
 
+func TestValidateDuplicates(t *testing.T) {
+	type dummy int
+	duplicateStringsCase := goconstants.Metadata[dummy]{
+		Name: "dummy",
+		Strings: map[dummy]string{
+			1: "one",
+			2: "two",
+			3: "one",
+		},
+	}
+	err := duplicateStringsCase.Validate()
+	if !errors.Is(err, goconstants.ErrDuplicateString) {
+		t.Errorf("validate didn't catch duplicate strings")
+	}
+	expectedMessage := `several values share the same string: "one" is used by 1, 3`
+	if err != nil && err.Error() != expectedMessage {
+		t.Errorf("expected error %s, got %s", expectedMessage, err)
+	}
+
+	duplicateJSONStringsCase := goconstants.Metadata[dummy]{
+		Name: "dummy",
+		Strings: map[dummy]string{
+			1: "one",
+			2: "two",
+		},
+		JSONStrings: map[dummy]string{
+			1: "same",
+			2: "same",
+		},
+	}
+	err = duplicateJSONStringsCase.Validate()
+	if !errors.Is(err, goconstants.ErrDuplicateJSONString) {
+		t.Errorf("validate didn't catch duplicate JSON strings")
+	}
+}
+
+func TestDiagnose(t *testing.T) {
+	type dummy int
+	validCase := goconstants.Metadata[dummy]{
+		Name:    "dummy",
+		Strings: map[dummy]string{1: "one"},
+	}
+	if err := validCase.Diagnose(); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	invalidCase := goconstants.Metadata[dummy]{
+		Strings: map[dummy]string{
+			1: "one",
+			2: "one",
+		},
+		JSONStrings: map[dummy]string{
+			1: "same",
+			2: "same",
+			3: "three",
+		},
+	}
+	err := invalidCase.Diagnose()
+	expectedErrors := []error{
+		goconstants.ErrNameMissing,
+		goconstants.ErrStringsIncoherence,
+		goconstants.ErrDuplicateString,
+		goconstants.ErrDuplicateJSONString,
+	}
+	for _, expectedError := range expectedErrors {
+		if !errors.Is(err, expectedError) {
+			t.Errorf("diagnose didn't report %v", expectedError)
+		}
+	}
+}
+
 type simpson int
 
 const (
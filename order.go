@@ -0,0 +1,131 @@
+package goconstants
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// ErrOrderIncoherence is returned by Validate when Order does not contain
+// exactly once each known value.
+var ErrOrderIncoherence = errors.New("Order does not contain exactly the known values")
+
+// Values returns the known values, in the order given by the Order field.
+// If Order is not set, the values are sorted by their underlying value for
+// numbers and strings, or by their string representation otherwise.
+// The returned slice could be modified freely.
+//
+// The order is computed once by Compile. Without Compile and without Order,
+// the values are sorted on each call of Values, Index and At, so compile the
+// Metadata before iterating with At.
+func (meta Metadata[T]) Values() []T {
+	values := meta.getValues()
+	result := make([]T, len(values))
+	copy(result, values)
+
+	return result
+}
+
+// Len returns the number of known values.
+func (meta Metadata[T]) Len() int {
+	return len(meta.getStrings())
+}
+
+// Index returns the position of a value in Values, and a boolean indicating
+// if the value is known.
+func (meta Metadata[T]) Index(v T) (int, bool) {
+	if meta.index != nil {
+		i, ok := meta.index.positions[v]
+		return i, ok
+	}
+
+	for i, value := range meta.getValues() {
+		if value == v {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+// At returns the value at the given position in Values.
+// It panics if i is out of range, like a slice access.
+func (meta Metadata[T]) At(i int) T {
+	return meta.getValues()[i]
+}
+
+// getValues returns the ordered values, from the index if the Metadata is
+// compiled. The returned slice must not be modified.
+func (meta Metadata[T]) getValues() []T {
+	if meta.index != nil {
+		return meta.index.values
+	}
+
+	if meta.Order != nil {
+		return meta.Order
+	}
+
+	return defaultOrder(meta.getStrings())
+}
+
+// defaultOrder returns the keys of the strings, sorted by their underlying
+// value if possible, or by their string otherwise.
+func defaultOrder[T comparable](strings map[T]string) []T {
+	values := make([]T, 0, len(strings))
+	for v := range strings {
+		values = append(values, v)
+	}
+
+	var zero T
+	var less func(a, b reflect.Value) bool
+	switch reflect.ValueOf(&zero).Elem().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		less = func(a, b reflect.Value) bool { return a.Int() < b.Int() }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		less = func(a, b reflect.Value) bool { return a.Uint() < b.Uint() }
+	case reflect.Float32, reflect.Float64:
+		less = func(a, b reflect.Value) bool { return a.Float() < b.Float() }
+	case reflect.String:
+		less = func(a, b reflect.Value) bool { return a.String() < b.String() }
+	default:
+		sort.Slice(values, func(i, j int) bool {
+			return strings[values[i]] < strings[values[j]]
+		})
+		return values
+	}
+
+	sort.Slice(values, func(i, j int) bool {
+		return less(reflect.ValueOf(values[i]), reflect.ValueOf(values[j]))
+	})
+
+	return values
+}
+
+// orderProblems returns the problems of the Order field.
+func (meta Metadata[T]) orderProblems() []error {
+	if meta.Order == nil {
+		return nil
+	}
+
+	var problems []error
+	strings := meta.getStrings()
+	seen := make(map[T]bool, len(meta.Order))
+	for _, v := range meta.Order {
+		if _, ok := strings[v]; !ok {
+			problems = append(problems, fmt.Errorf("%w: %#v is unknown", ErrOrderIncoherence, v))
+		}
+		if seen[v] {
+			problems = append(problems, fmt.Errorf("%w: %#v is present several times", ErrOrderIncoherence, v))
+		}
+		seen[v] = true
+	}
+
+	for _, v := range defaultOrder(strings) {
+		if !seen[v] {
+			problems = append(problems, fmt.Errorf("%w: %#v is missing", ErrOrderIncoherence, v))
+		}
+	}
+
+	return problems
+}
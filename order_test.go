@@ -0,0 +1,107 @@
+package goconstants_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/samonzeweb/goconstants"
+)
+
+func TestValues(t *testing.T) {
+	orderedMeta := cstMeta
+	orderedMeta.Order = []simpson{lisa, bart, maggie, homer, marge}
+
+	testCases := []struct {
+		name     string
+		meta     goconstants.Metadata[simpson]
+		expected []simpson
+	}{
+		{
+			name:     "default order",
+			meta:     cstMeta,
+			expected: []simpson{homer, marge, bart, lisa, maggie},
+		},
+		{
+			name:     "explicit order",
+			meta:     orderedMeta,
+			expected: []simpson{lisa, bart, maggie, homer, marge},
+		},
+		{
+			name:     "compiled default order",
+			meta:     cstMeta.Compile(),
+			expected: []simpson{homer, marge, bart, lisa, maggie},
+		},
+		{
+			name:     "compiled explicit order",
+			meta:     orderedMeta.Compile(),
+			expected: []simpson{lisa, bart, maggie, homer, marge},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			values := testCase.meta.Values()
+			if !reflect.DeepEqual(values, testCase.expected) {
+				t.Errorf("expected %v, got %v", testCase.expected, values)
+			}
+
+			if testCase.meta.Len() != len(testCase.expected) {
+				t.Errorf("expected length %d, got %d", len(testCase.expected), testCase.meta.Len())
+			}
+
+			for i, v := range testCase.expected {
+				if at := testCase.meta.At(i); at != v {
+					t.Errorf("expected %v at %d, got %v", v, i, at)
+				}
+
+				index, ok := testCase.meta.Index(v)
+				if !ok || index != i {
+					t.Errorf("expected index %d for %v, got %d (%t)", i, v, index, ok)
+				}
+			}
+
+			if _, ok := testCase.meta.Index(999); ok {
+				t.Errorf("an unknown value should not have an index")
+			}
+
+			// The returned slice is a copy.
+			values[0] = 999
+			if testCase.meta.At(0) == 999 {
+				t.Errorf("modifying the values should not alter the metadata")
+			}
+		})
+	}
+}
+
+func TestValuesStructOrder(t *testing.T) {
+	// Without Order, struct values are sorted by their string.
+	expected := []LanguageEnum{Go, Python, Rust}
+	values := metaLanguageEnum.Values()
+
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("expected %v, got %v", expected, values)
+	}
+}
+
+func TestValidateOrder(t *testing.T) {
+	testCases := []struct {
+		name  string
+		order []simpson
+	}{
+		{name: "missing value", order: []simpson{homer, marge, bart, lisa}},
+		{name: "unknown value", order: []simpson{homer, marge, bart, lisa, maggie, 999}},
+		{name: "duplicate value", order: []simpson{homer, marge, bart, lisa, maggie, homer}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			meta := cstMeta
+			meta.Order = testCase.order
+
+			if err := meta.Validate(); !errors.Is(err, goconstants.ErrOrderIncoherence) {
+				t.Errorf("validate didn't catch incoherent order, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package goconstants
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+// CaseFolding selects how the case is ignored when parsing representations.
+type CaseFolding int
+
+// Case foldings available in ParseOptions.
+const (
+	// NoFolding requires the same case.
+	NoFolding CaseFolding = iota
+	// ASCIIFolding ignores the case of ASCII letters only.
+	ASCIIFolding
+	// UnicodeFolding ignores the case of all letters, using Unicode simple
+	// case folding (like strings.EqualFold).
+	UnicodeFolding
+)
+
+// ErrUnknownCaseFolding is returned by Validate when the CaseFolding of
+// ParseOptions is not one of the defined values.
+var ErrUnknownCaseFolding = errors.New("unknown case folding")
+
+// ParseOptions configures how representations are matched by the parsing
+// helpers (FromStringHelper, UnmarshalJSONHelper, ...).
+// The zero value requires an exact match.
+// The marshalling helpers always use the representations as defined.
+type ParseOptions struct {
+	// CaseFolding selects how the case is ignored.
+	CaseFolding CaseFolding
+	// TrimSpace ignores leading and trailing white spaces.
+	TrimSpace bool
+	// Normalize, if set, is applied to the parsed representations and to the
+	// known ones before comparing them. Use it for Unicode normalization,
+	// ie with norm.NFC.String from golang.org/x/text/unicode/norm.
+	Normalize func(string) string
+}
+
+// key returns the form of a representation used for comparisons.
+func (opts ParseOptions) key(representation string) string {
+	if opts.TrimSpace {
+		representation = strings.TrimSpace(representation)
+	}
+
+	if opts.Normalize != nil {
+		representation = opts.Normalize(representation)
+	}
+
+	switch opts.CaseFolding {
+	case ASCIIFolding:
+		return strings.Map(foldASCII, representation)
+	case UnicodeFolding:
+		return strings.Map(foldUnicode, representation)
+	default:
+		return representation
+	}
+}
+
+// isExact checks if the options require an exact match.
+func (opts ParseOptions) isExact() bool {
+	return opts.CaseFolding == NoFolding && !opts.TrimSpace && opts.Normalize == nil
+}
+
+// isValid checks if the options contain only defined values.
+func (opts ParseOptions) isValid() bool {
+	return opts.CaseFolding >= NoFolding && opts.CaseFolding <= UnicodeFolding
+}
+
+// foldASCII returns the lower case version of an ASCII letter, other runes
+// are unchanged.
+func foldASCII(r rune) rune {
+	if 'A' <= r && r <= 'Z' {
+		return r + 'a' - 'A'
+	}
+
+	return r
+}
+
+// foldUnicode returns the smallest rune equivalent to r under Unicode simple
+// case folding, so that equivalent strings have the same folded form.
+func foldUnicode(r rune) rune {
+	smallest := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if f < smallest {
+			smallest = f
+		}
+	}
+
+	return smallest
+}
@@ -0,0 +1,164 @@
+package goconstants_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/samonzeweb/goconstants"
+)
+
+type cafe int
+
+const (
+	espresso cafe = 1
+	creme    cafe = 2
+	eclair   cafe = 3
+)
+
+var cafeMeta = goconstants.Metadata[cafe]{
+	Name: "cafe",
+	Strings: map[cafe]string{
+		espresso: "Espresso",
+		creme:    "Café Crème",
+		eclair:   "ÉCLAIR",
+	},
+}
+
+// composeAcute is a minimal normalization composing e and E followed by
+// a combining acute accent.
+func composeAcute(s string) string {
+	return strings.NewReplacer("e\u0301", "é", "E\u0301", "É").Replace(s)
+}
+
+func TestParseOptions(t *testing.T) {
+	testCases := []struct {
+		name     string
+		options  goconstants.ParseOptions
+		input    string
+		expected cafe
+	}{
+		{
+			name:     "exact match",
+			input:    "Espresso",
+			expected: espresso,
+		},
+		{
+			name:     "exact match required",
+			input:    "espresso",
+			expected: 0,
+		},
+		{
+			name:     "ascii folding",
+			options:  goconstants.ParseOptions{CaseFolding: goconstants.ASCIIFolding},
+			input:    "ESPRESSO",
+			expected: espresso,
+		},
+		{
+			name:     "ascii folding ignores non ascii letters",
+			options:  goconstants.ParseOptions{CaseFolding: goconstants.ASCIIFolding},
+			input:    "éclair",
+			expected: 0,
+		},
+		{
+			name:     "unicode folding",
+			options:  goconstants.ParseOptions{CaseFolding: goconstants.UnicodeFolding},
+			input:    "éclair",
+			expected: eclair,
+		},
+		{
+			name:     "trim space",
+			options:  goconstants.ParseOptions{TrimSpace: true},
+			input:    " Espresso\n",
+			expected: espresso,
+		},
+		{
+			name:     "trim space keeps inner spaces",
+			options:  goconstants.ParseOptions{TrimSpace: true},
+			input:    "Café  Crème",
+			expected: 0,
+		},
+		{
+			name:     "normalization",
+			options:  goconstants.ParseOptions{Normalize: composeAcute},
+			input:    "Cafe\u0301 Crème",
+			expected: creme,
+		},
+		{
+			name: "all options",
+			options: goconstants.ParseOptions{
+				CaseFolding: goconstants.UnicodeFolding,
+				TrimSpace:   true,
+				Normalize:   composeAcute,
+			},
+			input:    "  cafe\u0301 CRÈME ",
+			expected: creme,
+		},
+	}
+
+	for _, testCase := range testCases {
+		meta := cafeMeta
+		meta.Parsing = testCase.options
+
+		for _, compiled := range []bool{false, true} {
+			name := testCase.name
+			if compiled {
+				meta = meta.Compile()
+				name += " compiled"
+			}
+
+			t.Run(name, func(t *testing.T) {
+				value, ok := meta.FromStringHelper(testCase.input)
+
+				if value != testCase.expected {
+					t.Errorf("expected %v, got %v", testCase.expected, value)
+				}
+
+				if ok != (testCase.expected != 0) {
+					t.Errorf("unexpected validity %t", ok)
+				}
+			})
+		}
+	}
+}
+
+func TestParseOptionsMarshal(t *testing.T) {
+	meta := cafeMeta
+	meta.Parsing = goconstants.ParseOptions{CaseFolding: goconstants.UnicodeFolding, TrimSpace: true}
+
+	var value cafe
+	if err := meta.UnmarshalJSONHelper([]byte(`" café crème "`), &value); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	b, err := meta.MarshalJSONHelper(value)
+	if err != nil || string(b) != `"Café Crème"` {
+		t.Errorf("expected the canonical representation, got %s (%v)", string(b), err)
+	}
+}
+
+func TestValidateParseOptions(t *testing.T) {
+	meta := goconstants.Metadata[cafe]{
+		Name: "cafe",
+		Strings: map[cafe]string{
+			espresso: "Espresso",
+			creme:    "espresso ",
+		},
+	}
+
+	if err := meta.Validate(); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	meta.Parsing = goconstants.ParseOptions{CaseFolding: goconstants.ASCIIFolding, TrimSpace: true}
+	err := meta.Validate()
+	if !errors.Is(err, goconstants.ErrDuplicateString) {
+		t.Errorf("validate didn't catch strings colliding once normalized")
+	}
+
+	meta.Parsing = goconstants.ParseOptions{CaseFolding: goconstants.CaseFolding(42)}
+	err = meta.Validate()
+	if !errors.Is(err, goconstants.ErrUnknownCaseFolding) {
+		t.Errorf("validate didn't catch unknown case folding")
+	}
+}
@@ -0,0 +1,250 @@
+package goconstants
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/samonzeweb/goconstants/internal/naming"
+)
+
+// unspecifiedSuffix ends the name of the Protocol Buffers value numbered 0
+// when it's not a known value.
+const unspecifiedSuffix = "_UNSPECIFIED"
+
+// Errors about Protocol Buffers.
+var (
+	// ErrUnspecified is returned when converting the unspecified Protocol
+	// Buffers value, which has no constant value.
+	ErrUnspecified = errors.New("unspecified value")
+	// ErrNoProtoNumbers is returned by the Protocol Buffers helpers when
+	// ProtoNumbers is not set.
+	ErrNoProtoNumbers = errors.New("ProtoNumbers is not set")
+	// Errors returned by Validate.
+	ErrProtoIncoherence      = errors.New("ProtoNumbers or ProtoNames does not have the same keys than other strings")
+	ErrDuplicateProtoNumber  = errors.New("several values share the same Protocol Buffers number")
+	ErrDuplicateProtoName    = errors.New("several values share the same Protocol Buffers name")
+	ErrInvalidProtoName      = errors.New("invalid Protocol Buffers name")
+	ErrProtoNumbersUndefined = errors.New("ProtoNames or ProtoPrefix is set without ProtoNumbers")
+)
+
+// ProtoNumberHelper returns the Protocol Buffers number of a constant value,
+// applying the MarshalDeprecated policy.
+func (meta Metadata[T]) ProtoNumberHelper(v T) (int32, error) {
+	if meta.ProtoNumbers == nil {
+		return 0, ErrNoProtoNumbers
+	}
+
+	v, err := meta.marshalled(v)
+	if err != nil {
+		return 0, err
+	}
+
+	n, ok := meta.ProtoNumbers[v]
+	if !ok {
+		return 0, &UnknownValueError{Enum: meta.Name, Value: v}
+	}
+
+	return n, nil
+}
+
+// FromProtoNumberHelper converts a Protocol Buffers number to its associated
+// constant value, applying the ParseDeprecated policy.
+// It returns an error wrapping ErrUnspecified for 0 if it's not the number of
+// a known value, and an UnknownValueError for unknown numbers.
+func (meta Metadata[T]) FromProtoNumberHelper(n int32) (T, error) {
+	var zero T
+	if meta.ProtoNumbers == nil {
+		return zero, ErrNoProtoNumbers
+	}
+
+	value, ok := meta.fromProtoNumber(n)
+	if !ok {
+		if n == 0 {
+			return zero, fmt.Errorf("%w of %s", ErrUnspecified, meta.Name)
+		}
+		return zero, &UnknownValueError{Enum: meta.Name, Representation: strconv.Itoa(int(n))}
+	}
+
+	return meta.parsed(value)
+}
+
+// ProtoNameHelper returns the Protocol Buffers name of a constant value,
+// applying the MarshalDeprecated policy.
+func (meta Metadata[T]) ProtoNameHelper(v T) (string, error) {
+	if meta.ProtoNumbers == nil {
+		return "", ErrNoProtoNumbers
+	}
+
+	v, err := meta.marshalled(v)
+	if err != nil {
+		return "", err
+	}
+
+	return meta.toStringHelper(v, meta.getProtoNames())
+}
+
+// FromProtoNameHelper converts a Protocol Buffers name to its associated
+// constant value, applying the ParseDeprecated policy.
+// It returns an error wrapping ErrUnspecified for the name of the
+// unspecified value, and an UnknownValueError for unknown names.
+func (meta Metadata[T]) FromProtoNameHelper(name string) (T, error) {
+	var zero T
+	if meta.ProtoNumbers == nil {
+		return zero, ErrNoProtoNumbers
+	}
+
+	if unspecified := meta.protoUnspecified(); unspecified != "" && meta.Parsing.key(name) == meta.Parsing.key(unspecified) {
+		return zero, fmt.Errorf("%w of %s", ErrUnspecified, meta.Name)
+	}
+
+	return meta.decode(name, ProtoRepresentation)
+}
+
+// fromProtoNumber converts a Protocol Buffers number to its associated
+// constant value, using the index if available.
+func (meta Metadata[T]) fromProtoNumber(n int32) (T, bool) {
+	if meta.index != nil {
+		value, ok := meta.index.numbers[n]
+		return value, ok
+	}
+
+	for value, number := range meta.ProtoNumbers {
+		if number == n {
+			return value, true
+		}
+	}
+
+	var zero T
+	return zero, false
+}
+
+// getProtoNames returns ProtoNames if set, the names derived from the JSON
+// strings if only ProtoNumbers is set, or nil otherwise. The derived names
+// are computed once by Compile.
+func (meta Metadata[T]) getProtoNames() map[T]string {
+	if meta.index != nil {
+		return meta.index.protoNames
+	}
+
+	if meta.ProtoNames != nil || meta.ProtoNumbers == nil {
+		return meta.ProtoNames
+	}
+
+	prefix := meta.protoPrefix()
+	names := make(map[T]string, len(meta.ProtoNumbers))
+	for v, s := range meta.getJSONStrings() {
+		names[v] = prefix + "_" + screamingSnake(s)
+	}
+
+	return names
+}
+
+// protoPrefix returns ProtoPrefix, or the name in SCREAMING_SNAKE_CASE.
+func (meta Metadata[T]) protoPrefix() string {
+	if meta.ProtoPrefix != "" {
+		return meta.ProtoPrefix
+	}
+
+	return screamingSnake(meta.Name)
+}
+
+// protoUnspecified returns the name of the unspecified value, or a blank
+// string if 0 is the number of a known value.
+func (meta Metadata[T]) protoUnspecified() string {
+	if meta.index != nil {
+		return meta.index.protoUnspecified
+	}
+
+	if _, ok := meta.fromProtoNumber(0); ok {
+		return ""
+	}
+
+	return meta.protoPrefix() + unspecifiedSuffix
+}
+
+// protoProblems returns the problems of the Protocol Buffers numbers and
+// names.
+func (meta Metadata[T]) protoProblems() []error {
+	if meta.ProtoNumbers == nil {
+		if meta.ProtoNames != nil || meta.ProtoPrefix != "" {
+			return []error{ErrProtoNumbersUndefined}
+		}
+		return nil
+	}
+
+	var problems []error
+	known := meta.getStrings()
+	if !sameKeys(known, meta.ProtoNumbers) || meta.ProtoNames != nil && !sameKeys(known, meta.ProtoNames) {
+		problems = append(problems, ErrProtoIncoherence)
+	}
+
+	problems = append(problems, duplicateNumbers(meta.ProtoNumbers)...)
+
+	names := meta.getProtoNames()
+	unspecified := meta.Parsing.key(meta.protoUnspecified())
+	for _, v := range sortedKeys(names) {
+		switch name := names[v]; {
+		case !isProtoIdentifier(name):
+			problems = append(problems, fmt.Errorf("%w: %q for %#v", ErrInvalidProtoName, name, v))
+		case unspecified != "" && meta.Parsing.key(name) == unspecified:
+			problems = append(problems, fmt.Errorf("%w: %q is used by %#v and the unspecified value",
+				ErrDuplicateProtoName, name, v))
+		}
+	}
+	problems = append(problems, duplicates(names, meta.Parsing, ErrDuplicateProtoName)...)
+
+	return problems
+}
+
+// duplicateNumbers returns an error for each Protocol Buffers number shared
+// by several values, sorted by number.
+func duplicateNumbers[T comparable](numbers map[T]int32) []error {
+	users := make(map[int32][]string, len(numbers))
+	for v, n := range numbers {
+		users[n] = append(users[n], fmt.Sprintf("%#v", v))
+	}
+
+	shared := make([]int32, 0)
+	for n, values := range users {
+		if len(values) > 1 {
+			shared = append(shared, n)
+		}
+	}
+	sort.Slice(shared, func(i, j int) bool { return shared[i] < shared[j] })
+
+	var problems []error
+	for _, n := range shared {
+		if values := users[n]; len(values) > 1 {
+			sort.Strings(values)
+			problems = append(problems, fmt.Errorf("%w: %d is used by %s",
+				ErrDuplicateProtoNumber, n, strings.Join(values, ", ")))
+		}
+	}
+
+	return problems
+}
+
+// isProtoIdentifier checks if a name is a valid Protocol Buffers identifier.
+func isProtoIdentifier(name string) bool {
+	for i, r := range name {
+		letter := r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+		if !letter && (i == 0 || r != '_' && (r < '0' || r > '9')) {
+			return false
+		}
+	}
+
+	return name != ""
+}
+
+// screamingSnake returns a string in SCREAMING_SNAKE_CASE, or unchanged if
+// it can't be represented as an identifier, for Validate to report it.
+func screamingSnake(s string) string {
+	if words, ok := naming.ASCIIWords(s); ok {
+		return strings.ToUpper(strings.Join(words, "_"))
+	}
+
+	return s
+}
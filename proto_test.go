@@ -0,0 +1,213 @@
+package goconstants_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/samonzeweb/goconstants"
+)
+
+// protoMeta returns cstMeta with Protocol Buffers numbers, the names being
+// derived from the JSON strings.
+func protoMeta() goconstants.Metadata[simpson] {
+	meta := cstMeta
+	meta.ProtoNumbers = map[simpson]int32{homer: 1, marge: 2, bart: 3, lisa: 4, maggie: 5}
+	meta.ProtoPrefix = "SIMPSON"
+
+	return meta
+}
+
+func TestProtoHelpers(t *testing.T) {
+	meta := protoMeta()
+	if err := meta.Validate(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	for _, compiled := range []bool{false, true} {
+		if compiled {
+			meta = meta.Compile()
+		}
+
+		n, err := meta.ProtoNumberHelper(bart)
+		if err != nil || n != 3 {
+			t.Errorf("expected 3, got %d (%v, compiled: %t)", n, err, compiled)
+		}
+
+		value, err := meta.FromProtoNumberHelper(4)
+		if err != nil || value != lisa {
+			t.Errorf("expected %v, got %v (%v, compiled: %t)", lisa, value, err, compiled)
+		}
+
+		name, err := meta.ProtoNameHelper(bart)
+		if err != nil || name != "SIMPSON_BART_SIMPSON" {
+			t.Errorf("expected SIMPSON_BART_SIMPSON, got %s (%v, compiled: %t)", name, err, compiled)
+		}
+
+		value, err = meta.FromProtoNameHelper("SIMPSON_MARGE_SIMPSON")
+		if err != nil || value != marge {
+			t.Errorf("expected %v, got %v (%v, compiled: %t)", marge, value, err, compiled)
+		}
+
+		if _, err := meta.FromProtoNumberHelper(0); !errors.Is(err, goconstants.ErrUnspecified) {
+			t.Errorf("expected error %v, got %v (compiled: %t)", goconstants.ErrUnspecified, err, compiled)
+		}
+
+		if _, err := meta.FromProtoNameHelper("SIMPSON_UNSPECIFIED"); !errors.Is(err, goconstants.ErrUnspecified) {
+			t.Errorf("expected error %v, got %v (compiled: %t)", goconstants.ErrUnspecified, err, compiled)
+		}
+
+		if _, err := meta.FromProtoNumberHelper(42); !errors.Is(err, goconstants.ErrUnknownValue) {
+			t.Errorf("expected error %v, got %v (compiled: %t)", goconstants.ErrUnknownValue, err, compiled)
+		}
+
+		_, err = meta.FromProtoNameHelper("SIMPSON_BART_SIMPSONS")
+		var unknownErr *goconstants.UnknownValueError
+		if !errors.As(err, &unknownErr) || unknownErr.Representation != "SIMPSON_BART_SIMPSONS" {
+			t.Errorf("expected an UnknownValueError, got %v (compiled: %t)", err, compiled)
+		}
+
+		if _, err := meta.ProtoNumberHelper(999); !errors.Is(err, goconstants.ErrUnknownValue) {
+			t.Errorf("expected error %v, got %v (compiled: %t)", goconstants.ErrUnknownValue, err, compiled)
+		}
+	}
+}
+
+func TestProtoHelpersWithoutNumbers(t *testing.T) {
+	if _, err := cstMeta.ProtoNumberHelper(homer); !errors.Is(err, goconstants.ErrNoProtoNumbers) {
+		t.Errorf("expected error %v, got %v", goconstants.ErrNoProtoNumbers, err)
+	}
+
+	if _, err := cstMeta.FromProtoNameHelper("CST_HOMER_SIMPSON"); !errors.Is(err, goconstants.ErrNoProtoNumbers) {
+		t.Errorf("expected error %v, got %v", goconstants.ErrNoProtoNumbers, err)
+	}
+}
+
+func TestProtoZeroValue(t *testing.T) {
+	meta := protoMeta()
+	meta.ProtoNumbers = map[simpson]int32{homer: 0, marge: 2, bart: 3, lisa: 4, maggie: 5}
+	meta.ProtoNames = map[simpson]string{homer: "HOMER", marge: "MARGE", bart: "BART", lisa: "LISA", maggie: "MAGGIE"}
+	if err := meta.Validate(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	value, err := meta.FromProtoNumberHelper(0)
+	if err != nil || value != homer {
+		t.Errorf("expected %v, got %v (%v)", homer, value, err)
+	}
+
+	if info := meta.Info(); info.ProtoUnspecified != "" || info.Values[0].ProtoName != "HOMER" {
+		t.Errorf("unexpected info %v", info)
+	}
+}
+
+func TestProtoDerivedNames(t *testing.T) {
+	meta := protoMeta()
+	meta.JSONStrings = map[simpson]string{homer: "homère", marge: "marge", bart: "bart", lisa: "lisa", maggie: "maggie"}
+
+	if name, err := meta.ProtoNameHelper(homer); err != nil || name != "SIMPSON_HOMERE" {
+		t.Errorf("expected SIMPSON_HOMERE, got %s (%v)", name, err)
+	}
+
+	compiled := meta.Compile()
+	if value, err := compiled.FromProtoNameHelper("SIMPSON_HOMERE"); err != nil || value != homer {
+		t.Errorf("expected %v, got %v (%v)", homer, value, err)
+	}
+	if _, err := compiled.FromProtoNameHelper("SIMPSON_UNSPECIFIED"); !errors.Is(err, goconstants.ErrUnspecified) {
+		t.Errorf("expected error %v, got %v", goconstants.ErrUnspecified, err)
+	}
+
+	testCases := []struct {
+		name          string
+		homer         string
+		expectedError error
+	}{
+		{name: "not representable", homer: "東京", expectedError: goconstants.ErrInvalidProtoName},
+		{name: "duplicate", homer: "MARGE", expectedError: goconstants.ErrDuplicateProtoName},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			meta := protoMeta()
+			meta.JSONStrings = map[simpson]string{homer: testCase.homer, marge: "marge", bart: "bart", lisa: "lisa", maggie: "maggie"}
+
+			if err := meta.Validate(); !errors.Is(err, testCase.expectedError) {
+				t.Errorf("expected error %v, got %v", testCase.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestProtoDeprecated(t *testing.T) {
+	meta := protoMeta()
+	meta.Deprecations = map[simpson]goconstants.Deprecation[simpson]{
+		maggie: {Replacement: lisa, HasReplacement: true},
+	}
+	meta.MarshalDeprecated = goconstants.ReplaceDeprecated
+	meta.ParseDeprecated = goconstants.RejectDeprecated
+
+	if n, err := meta.ProtoNumberHelper(maggie); err != nil || n != 4 {
+		t.Errorf("expected 4, got %d (%v)", n, err)
+	}
+
+	if _, err := meta.FromProtoNumberHelper(5); !errors.Is(err, goconstants.ErrDeprecatedValue) {
+		t.Errorf("expected error %v, got %v", goconstants.ErrDeprecatedValue, err)
+	}
+}
+
+func TestValidateProto(t *testing.T) {
+	testCases := []struct {
+		name          string
+		numbers       map[simpson]int32
+		names         map[simpson]string
+		prefix        string
+		expectedError error
+	}{
+		{
+			name:          "names without numbers",
+			names:         map[simpson]string{homer: "HOMER"},
+			expectedError: goconstants.ErrProtoNumbersUndefined,
+		},
+		{
+			name:          "missing number",
+			numbers:       map[simpson]int32{homer: 1},
+			expectedError: goconstants.ErrProtoIncoherence,
+		},
+		{
+			name:          "duplicate number",
+			numbers:       map[simpson]int32{homer: 1, marge: 1, bart: 3, lisa: 4, maggie: 5},
+			expectedError: goconstants.ErrDuplicateProtoNumber,
+		},
+		{
+			name:          "invalid name",
+			numbers:       map[simpson]int32{homer: 1, marge: 2, bart: 3, lisa: 4, maggie: 5},
+			names:         map[simpson]string{homer: "1HOMER", marge: "MARGE", bart: "BART", lisa: "LISA", maggie: "MAGGIE"},
+			expectedError: goconstants.ErrInvalidProtoName,
+		},
+		{
+			name:          "duplicate name",
+			numbers:       map[simpson]int32{homer: 1, marge: 2, bart: 3, lisa: 4, maggie: 5},
+			names:         map[simpson]string{homer: "HOMER", marge: "HOMER", bart: "BART", lisa: "LISA", maggie: "MAGGIE"},
+			expectedError: goconstants.ErrDuplicateProtoName,
+		},
+		{
+			name:          "unspecified name",
+			numbers:       map[simpson]int32{homer: 1, marge: 2, bart: 3, lisa: 4, maggie: 5},
+			names:         map[simpson]string{homer: "S_UNSPECIFIED", marge: "MARGE", bart: "BART", lisa: "LISA", maggie: "MAGGIE"},
+			prefix:        "S",
+			expectedError: goconstants.ErrDuplicateProtoName,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			meta := cstMeta
+			meta.ProtoNumbers = testCase.numbers
+			meta.ProtoNames = testCase.names
+			meta.ProtoPrefix = testCase.prefix
+
+			if err := meta.Validate(); !errors.Is(err, testCase.expectedError) {
+				t.Errorf("expected error %v, got %v", testCase.expectedError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package goconstants
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// ErrAlreadyRegistered is returned by Register and Bind if a constant type
+// with the same name, or the same type, is already registered.
+var ErrAlreadyRegistered = errors.New("the constant type is already registered")
+
+// registry contains the registered constant types.
+var registry = struct {
+	sync.RWMutex
+	byName map[string]*entry
+	byType map[reflect.Type]*entry
+}{
+	byName: make(map[string]*entry),
+	byType: make(map[reflect.Type]*entry),
+}
+
+// entry is a registered constant type.
+type entry struct {
+	info     Info
+	diagnose func() error
+	// descriptor is the *Descriptor[T] bound to the type, nil if the type
+	// is registered with its Metadata.
+	descriptor any
+}
+
+// Register adds a copy of the Metadata to the registry, allowing to find it
+// by name or by type, and to validate it with ValidateAll.
+// The registry is optional, only register constant types which have to be
+// discovered by other tools (exporters, handlers, tests, ...). The types
+// bound to a Descriptor (see Bind) are registered too.
+// The Metadata is not validated, use ValidateAll, but its name must be
+// unique as well as its type.
+func Register[T comparable](meta Metadata[T]) error {
+	if meta.Name == "" {
+		return ErrNameMissing
+	}
+
+	meta = meta.clone()
+	return register(&entry{info: meta.Info(), diagnose: meta.Diagnose})
+}
+
+// register adds the entry to the registry, if its name and its type are not
+// already registered.
+func register(e *entry) error {
+	registry.Lock()
+	defer registry.Unlock()
+
+	if existing, ok := registry.byType[e.info.Type]; ok {
+		if existing.descriptor != nil && e.descriptor != nil {
+			return fmt.Errorf("%w: %s", ErrAlreadyBound, e.info.Type)
+		}
+		return fmt.Errorf("%w: type %s", ErrAlreadyRegistered, e.info.Type)
+	}
+	if _, ok := registry.byName[e.info.Name]; ok {
+		return fmt.Errorf("%w: name %s", ErrAlreadyRegistered, e.info.Name)
+	}
+
+	registry.byName[e.info.Name] = e
+	registry.byType[e.info.Type] = e
+	return nil
+}
+
+// MustRegister is like Register but panics if the constant type can't be
+// registered.
+func MustRegister[T comparable](meta Metadata[T]) {
+	if err := Register(meta); err != nil {
+		panic(err)
+	}
+}
+
+// Lookup returns the description of the registered constant type having the
+// given name, and a boolean indicating if there is one.
+func Lookup(name string) (Info, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	e, ok := registry.byName[name]
+	if !ok {
+		return Info{}, false
+	}
+
+	return e.info.clone(), true
+}
+
+// LookupType returns the description of the registered constant type t, and
+// a boolean indicating if there is one.
+func LookupType(t reflect.Type) (Info, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	e, ok := registry.byType[t]
+	if !ok {
+		return Info{}, false
+	}
+
+	return e.info.clone(), true
+}
+
+// Registered returns the descriptions of all registered constant types,
+// sorted by name.
+func Registered() []Info {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	infos := make([]Info, 0, len(registry.byName))
+	for _, e := range registry.byName {
+		infos = append(infos, e.info.clone())
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Name < infos[j].Name
+	})
+
+	return infos
+}
+
+// ValidateAll validates all registered constant types, and returns all
+// problems found, joined with errors.Join. Call it when the program starts
+// or in a dedicated test.
+func ValidateAll() error {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	names := make([]string, 0, len(registry.byName))
+	for name := range registry.byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var problems []error
+	for _, name := range names {
+		if err := registry.byName[name].diagnose(); err != nil {
+			problems = append(problems, fmt.Errorf("invalid %s metadata: %w", name, err))
+		}
+	}
+
+	return errors.Join(problems...)
+}
@@ -0,0 +1,148 @@
+package goconstants_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/samonzeweb/goconstants"
+)
+
+type registered int
+
+type registeredTwice int
+
+type invalidRegistered int
+
+type boundRegistered int
+
+func TestRegister(t *testing.T) {
+	goconstants.IsolateRegistry(t)
+	goconstants.MustRegister(goconstants.Metadata[unbound]{
+		Name:    "another",
+		Strings: map[unbound]string{1: "one"},
+	})
+
+	err := goconstants.Register(goconstants.Metadata[registered]{
+		Name:    "registered",
+		Strings: map[registered]string{1: "one", 2: "two"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	info, ok := goconstants.Lookup("registered")
+	if !ok || info.Type != reflect.TypeOf(registered(0)) || len(info.Values) != 2 {
+		t.Errorf("unexpected lookup result %v (%t)", info, ok)
+	}
+
+	// The registry is not altered by changes of the returned info.
+	info.Values[0].String = "altered"
+
+	info, ok = goconstants.LookupType(reflect.TypeOf(registered(0)))
+	if !ok || info.Name != "registered" || info.Values[0].String != "one" {
+		t.Errorf("unexpected lookup result %v (%t)", info, ok)
+	}
+
+	if _, ok := goconstants.Lookup("unknown"); ok {
+		t.Errorf("unknown should not be registered")
+	}
+
+	names := []string{}
+	for _, info := range goconstants.Registered() {
+		names = append(names, info.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"another", "registered"}) {
+		t.Errorf("expected the registered types sorted by name, got %v", names)
+	}
+}
+
+func TestRegisterTwice(t *testing.T) {
+	meta := goconstants.Metadata[registeredTwice]{
+		Name:    "registeredTwice",
+		Strings: map[registeredTwice]string{1: "one"},
+	}
+	goconstants.IsolateRegistry(t)
+	goconstants.MustRegister(meta)
+
+	testCases := []struct {
+		name          string
+		err           error
+		expectedError error
+	}{
+		{
+			name:          "same name",
+			err:           goconstants.Register(goconstants.Metadata[unbound]{Name: "registeredTwice"}),
+			expectedError: goconstants.ErrAlreadyRegistered,
+		},
+		{
+			name:          "same type",
+			err:           goconstants.Register(goconstants.Metadata[registeredTwice]{Name: "other"}),
+			expectedError: goconstants.ErrAlreadyRegistered,
+		},
+		{
+			name:          "bound type",
+			err:           goconstants.Bind(goconstants.MustNew("bound", goconstants.WithStrings(meta.Strings))),
+			expectedError: goconstants.ErrAlreadyRegistered,
+		},
+		{
+			name:          "no name",
+			err:           goconstants.Register(goconstants.Metadata[unbound]{}),
+			expectedError: goconstants.ErrNameMissing,
+		},
+	}
+
+	for _, testCase := range testCases {
+		if !errors.Is(testCase.err, testCase.expectedError) {
+			t.Errorf("%s: expected error %v, got %v", testCase.name, testCase.expectedError, testCase.err)
+		}
+	}
+
+	if _, ok := goconstants.Lookup("other"); ok {
+		t.Errorf("a failed registration should not be kept")
+	}
+}
+
+func TestBindRegisters(t *testing.T) {
+	if info, ok := goconstants.LookupType(reflect.TypeOf(planet(0))); !ok || info.Name != "Planet" {
+		t.Errorf("the bound planet type should be registered, got %v (%t)", info, ok)
+	}
+
+	goconstants.IsolateRegistry(t)
+	d := goconstants.MustBind(goconstants.MustNew("boundRegistered",
+		goconstants.WithStrings(map[boundRegistered]string{1: "one"})))
+
+	if bound, ok := goconstants.Bound[boundRegistered](); !ok || bound != d {
+		t.Errorf("expected the descriptor, got %v (%t)", bound, ok)
+	}
+
+	infos := goconstants.Registered()
+	if len(infos) != 1 || infos[0].Name != "boundRegistered" || infos[0].Values[0].String != "one" {
+		t.Errorf("expected the bound type to be registered, got %v", infos)
+	}
+
+	if err := goconstants.ValidateAll(); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	err := goconstants.Register(goconstants.Metadata[boundRegistered]{Name: "other"})
+	if !errors.Is(err, goconstants.ErrAlreadyRegistered) {
+		t.Errorf("expected error %v, got %v", goconstants.ErrAlreadyRegistered, err)
+	}
+}
+
+func TestValidateAll(t *testing.T) {
+	goconstants.IsolateRegistry(t)
+	goconstants.MustRegister(goconstants.Metadata[invalidRegistered]{
+		Name:        "invalidRegistered",
+		Strings:     map[invalidRegistered]string{1: "one", 2: "one"},
+		JSONStrings: map[invalidRegistered]string{1: "one"},
+	})
+
+	err := goconstants.ValidateAll()
+	for _, expected := range []error{goconstants.ErrStringsIncoherence, goconstants.ErrDuplicateString} {
+		if !errors.Is(err, expected) {
+			t.Errorf("expected error %v, got %v", expected, err)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package goconstants
+
+import "errors"
+
+// Representation identifies one of the sets of strings of a Metadata.
+type Representation int
+
+// Representations available in Metadata.
+const (
+	// JSONRepresentation uses JSONStrings, or Strings if not set.
+	JSONRepresentation Representation = iota
+	// StringRepresentation uses Strings, or JSONStrings if not set.
+	StringRepresentation
+	// DBRepresentation uses DBStrings.
+	DBRepresentation
+	// ProtoRepresentation uses the Protocol Buffers names (see ProtoNames).
+	ProtoRepresentation
+	// GraphQLRepresentation uses the GraphQL names (see GraphQLStrings).
+	GraphQLRepresentation
+)
+
+// ErrUnknownRepresentation is returned by Validate when a Representation
+// field does not contain one of the defined representations.
+var ErrUnknownRepresentation = errors.New("unknown representation")
+
+// representations lists all valid representations.
+var representations = []Representation{
+	JSONRepresentation,
+	StringRepresentation,
+	DBRepresentation,
+	ProtoRepresentation,
+	GraphQLRepresentation,
+}
+
+// String returns the name of the representation.
+// It implements the fmt.Stringer interface.
+func (r Representation) String() string {
+	switch r {
+	case JSONRepresentation:
+		return "json"
+	case StringRepresentation:
+		return "string"
+	case DBRepresentation:
+		return "db"
+	case ProtoRepresentation:
+		return "proto"
+	case GraphQLRepresentation:
+		return "graphql"
+	default:
+		return ""
+	}
+}
+
+// isValid checks if the representation is known.
+func (r Representation) isValid() bool {
+	for _, known := range representations {
+		if r == known {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getRepresentation returns the strings of the given representation.
+func (meta Metadata[T]) getRepresentation(r Representation) map[T]string {
+	switch r {
+	case StringRepresentation:
+		return meta.getStrings()
+	case DBRepresentation:
+		return meta.DBStrings
+	case ProtoRepresentation:
+		return meta.getProtoNames()
+	case GraphQLRepresentation:
+		return meta.getGraphQLStrings()
+	default:
+		return meta.getJSONStrings()
+	}
+}
+
+// parse converts a representation, or one of its aliases, to its associated
+// constant value, and a boolean indicating if the value is valid.
+func (meta Metadata[T]) parse(representation string, r Representation) (T, bool) {
+	value, ok := meta.fromStringHelper(representation, meta.getRepresentation(r), meta.index.reverse(r))
+	if ok {
+		return value, true
+	}
+
+	return meta.fromAlias(representation, r)
+}
+
+// decode converts a representation, or one of its aliases, to its associated
+// constant value, applying the ParseDeprecated policy.
+func (meta Metadata[T]) decode(representation string, r Representation) (T, error) {
+	value, ok := meta.parse(representation, r)
+	if !ok {
+		return value, meta.unknownRepresentation(representation, r)
+	}
+
+	return meta.parsed(value)
+}
@@ -0,0 +1,167 @@
+package goconstants
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// ErrNotStorable is returned by ValueHelper when DBStrings is not set and the
+// constant type is not an integer type.
+var ErrNotStorable = errors.New("the value can't be stored without DBStrings")
+
+// dbFormat is the format name used in DecodeError by ScanHelper.
+const dbFormat = "database"
+
+// ValueHelper allows the implementation of driver.Valuer for the associated
+// constant type.
+// The value is stored using DBStrings if set, or as an integer otherwise.
+// With Flags, the DBStrings of a combination are joined by FlagSeparator.
+func (meta Metadata[T]) ValueHelper(v T) (driver.Value, error) {
+	v, err := meta.marshalled(v)
+	if err != nil {
+		return nil, fmt.Errorf("unable to store %s value: %w", meta.Name, err)
+	}
+
+	if meta.DBStrings != nil {
+		var representation string
+		if meta.Flags {
+			representation, err = meta.formatFlags(v, DBRepresentation)
+		} else {
+			representation, err = meta.toStringHelper(v, meta.DBStrings)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to store %s value: %w", meta.Name, err)
+		}
+
+		return representation, nil
+	}
+
+	if !meta.IsValidHelper(v) {
+		return nil, fmt.Errorf("unable to store %s value: %w", meta.Name, meta.invalidValue(v))
+	}
+
+	n, ok := toInt64(v)
+	if !ok {
+		return nil, fmt.Errorf("unable to store %s value: %w", meta.Name, ErrNotStorable)
+	}
+
+	return n, nil
+}
+
+// ScanHelper allows the implementation of sql.Scanner for the associated
+// constant type.
+// The source could be a string or a []byte (using DBStrings if set, or
+// containing an integer otherwise), or an int64.
+// It returns a DecodeError if the source can't be converted, an
+// UnknownValueError if the value is unknown, and a DeprecatedValueError if
+// the value is deprecated and rejected.
+func (meta Metadata[T]) ScanHelper(src any, v *T) error {
+	switch src := src.(type) {
+	case string:
+		return meta.scanString(src, v)
+	case []byte:
+		return meta.scanString(string(src), v)
+	case int64:
+		return meta.scanInt64(src, v)
+	default:
+		return &DecodeError{
+			Enum:   meta.Name,
+			Format: dbFormat,
+			Err:    fmt.Errorf("unsupported source type %T", src),
+		}
+	}
+}
+
+// scanString converts a string read from a database.
+func (meta Metadata[T]) scanString(representation string, v *T) error {
+	if meta.DBStrings == nil {
+		n, err := strconv.ParseInt(representation, 10, 64)
+		if err != nil {
+			return &DecodeError{Enum: meta.Name, Format: dbFormat, Err: err}
+		}
+
+		return meta.scanInt64(n, v)
+	}
+
+	decode := meta.decode
+	if meta.Flags {
+		decode = meta.decodeFlags
+	}
+
+	value, err := decode(representation, DBRepresentation)
+	if err != nil {
+		return err
+	}
+
+	*v = value
+	return nil
+}
+
+// scanInt64 converts an integer read from a database.
+func (meta Metadata[T]) scanInt64(n int64, v *T) error {
+	value, ok := fromInt64[T](n)
+	if !ok {
+		return &DecodeError{
+			Enum:   meta.Name,
+			Format: dbFormat,
+			Err:    fmt.Errorf("unable to convert %d to %T", n, value),
+		}
+	}
+
+	if !meta.IsValidHelper(value) {
+		return meta.invalidValue(value)
+	}
+
+	value, err := meta.parsed(value)
+	if err != nil {
+		return err
+	}
+
+	*v = value
+	return nil
+}
+
+// toInt64 converts a value of an integer type to int64. The boolean is false
+// if the type is not an integer type or if the value overflows.
+func toInt64[T comparable](v T) (int64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u := rv.Uint()
+		if u > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(u), true
+	default:
+		return 0, false
+	}
+}
+
+// fromInt64 converts an int64 to a value of an integer type. The boolean is
+// false if the type is not an integer type or if the value overflows.
+func fromInt64[T comparable](n int64) (T, bool) {
+	var value T
+	rv := reflect.ValueOf(&value).Elem()
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if rv.OverflowInt(n) {
+			return value, false
+		}
+		rv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if n < 0 || rv.OverflowUint(uint64(n)) {
+			return value, false
+		}
+		rv.SetUint(uint64(n))
+	default:
+		return value, false
+	}
+
+	return value, true
+}
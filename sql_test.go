@@ -0,0 +1,208 @@
+package goconstants_test
+
+import (
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/samonzeweb/goconstants"
+)
+
+func TestValueHelper(t *testing.T) {
+	dbStringsMeta := cstMeta
+	dbStringsMeta.DBStrings = map[simpson]string{
+		homer:  "HOMER",
+		marge:  "MARGE",
+		bart:   "BART",
+		lisa:   "LISA",
+		maggie: "MAGGIE",
+	}
+
+	testCases := []struct {
+		name          string
+		meta          goconstants.Metadata[simpson]
+		input         simpson
+		expected      driver.Value
+		expectedError error
+	}{
+		{
+			name:     "integer",
+			meta:     cstMeta,
+			input:    bart,
+			expected: int64(3),
+		},
+		{
+			name:          "invalid integer",
+			meta:          cstMeta,
+			input:         999,
+			expectedError: goconstants.ErrUnknownValue,
+		},
+		{
+			name:     "db string",
+			meta:     dbStringsMeta,
+			input:    bart,
+			expected: "BART",
+		},
+		{
+			name:          "invalid db string",
+			meta:          dbStringsMeta,
+			input:         999,
+			expectedError: goconstants.ErrUnknownValue,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			value, err := testCase.meta.ValueHelper(testCase.input)
+
+			if value != testCase.expected {
+				t.Errorf("expected %#v, got %#v", testCase.expected, value)
+			}
+
+			if !errors.Is(err, testCase.expectedError) {
+				t.Errorf("expected error %v, got %v", testCase.expectedError, err)
+			}
+		})
+	}
+
+	value, err := Rust.Value()
+	if value != "rust" || err != nil {
+		t.Errorf("expected rust, got %#v (%v)", value, err)
+	}
+}
+
+func TestValueHelperNotStorable(t *testing.T) {
+	meta := goconstants.Metadata[LanguageEnum]{
+		Name:    "LanguageEnum",
+		Strings: map[LanguageEnum]string{Go: "Go"},
+	}
+
+	_, err := meta.ValueHelper(Go)
+	if !errors.Is(err, goconstants.ErrNotStorable) {
+		t.Errorf("expected error %v, got %v", goconstants.ErrNotStorable, err)
+	}
+}
+
+func TestScanHelper(t *testing.T) {
+	dbStringsMeta := cstMeta
+	dbStringsMeta.DBStrings = map[simpson]string{
+		homer:  "HOMER",
+		marge:  "MARGE",
+		bart:   "BART",
+		lisa:   "LISA",
+		maggie: "MAGGIE",
+	}
+
+	var decodeError *goconstants.DecodeError
+	testCases := []struct {
+		name          string
+		meta          goconstants.Metadata[simpson]
+		input         any
+		expected      simpson
+		expectedError any
+	}{
+		{
+			name:     "int64",
+			meta:     cstMeta,
+			input:    int64(4),
+			expected: lisa,
+		},
+		{
+			name:     "integer as string",
+			meta:     cstMeta,
+			input:    "4",
+			expected: lisa,
+		},
+		{
+			name:     "integer as bytes",
+			meta:     cstMeta,
+			input:    []byte("4"),
+			expected: lisa,
+		},
+		{
+			name:          "unknown integer",
+			meta:          cstMeta,
+			input:         int64(42),
+			expectedError: goconstants.ErrUnknownValue,
+		},
+		{
+			name:          "malformed integer",
+			meta:          cstMeta,
+			input:         "four",
+			expectedError: &decodeError,
+		},
+		{
+			name:     "db string",
+			meta:     dbStringsMeta,
+			input:    "LISA",
+			expected: lisa,
+		},
+		{
+			name:     "db string as bytes",
+			meta:     dbStringsMeta,
+			input:    []byte("LISA"),
+			expected: lisa,
+		},
+		{
+			name:          "unknown db string",
+			meta:          dbStringsMeta,
+			input:         "NED",
+			expectedError: goconstants.ErrUnknownValue,
+		},
+		{
+			name:          "unsupported type",
+			meta:          cstMeta,
+			input:         4.0,
+			expectedError: &decodeError,
+		},
+		{
+			name:          "null",
+			meta:          cstMeta,
+			input:         nil,
+			expectedError: &decodeError,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var value simpson
+			err := testCase.meta.ScanHelper(testCase.input, &value)
+
+			if value != testCase.expected {
+				t.Errorf("expected %v, got %v", testCase.expected, value)
+			}
+
+			switch expectedError := testCase.expectedError.(type) {
+			case nil:
+				if err != nil {
+					t.Errorf("unexpected error %v", err)
+				}
+			case error:
+				if !errors.Is(err, expectedError) {
+					t.Errorf("expected error %v, got %v", expectedError, err)
+				}
+			default:
+				if !errors.As(err, expectedError) {
+					t.Errorf("expected error of type %T, got %v", expectedError, err)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateDBStrings(t *testing.T) {
+	meta := cstMeta
+	meta.DBStrings = map[simpson]string{
+		homer: "HOMER",
+		marge: "HOMER",
+	}
+
+	err := meta.Diagnose()
+	if !errors.Is(err, goconstants.ErrDBStringsIncoherence) {
+		t.Errorf("validate didn't catch incoherent db strings")
+	}
+
+	if !errors.Is(err, goconstants.ErrDuplicateDBString) {
+		t.Errorf("validate didn't catch duplicate db strings")
+	}
+}
@@ -0,0 +1,133 @@
+package goconstants
+
+import (
+	"fmt"
+	"sort"
+	"unicode/utf8"
+)
+
+// maxSuggestions is the maximal number of suggestions in parse errors.
+const maxSuggestions = 3
+
+// maxSuggestedLength is the maximal length, in runes, of the unknown
+// representations for which suggestions are searched.
+const maxSuggestedLength = 64
+
+// ParseHelper converts a string to its associated constant value, like
+// FromStringHelper, but returns an UnknownValueError containing suggestions
+// if the string is unknown. It's useful to validate user inputs, like
+// command line flags.
+func (meta Metadata[T]) ParseHelper(representation string) (T, error) {
+	if meta.Flags {
+		return meta.decodeFlags(representation, StringRepresentation)
+	}
+
+	return meta.decode(representation, StringRepresentation)
+}
+
+// unknownRepresentation returns the error of an unknown representation,
+// with the close representations and, if the number of values does not
+// exceed ListAccepted, the list of accepted representations.
+func (meta Metadata[T]) unknownRepresentation(representation string, r Representation) *UnknownValueError {
+	err := &UnknownValueError{
+		Enum:           meta.Name,
+		Representation: representation,
+		Suggestions:    meta.suggest(representation, r),
+	}
+
+	if meta.Len() <= meta.ListAccepted {
+		strings := meta.getRepresentation(r)
+		for _, v := range meta.getValues() {
+			err.Accepted = append(err.Accepted, strings[v])
+		}
+	}
+
+	return err
+}
+
+// suggest returns the representations close to the given one, the closest
+// first. Aliases are not suggested, and there is no suggestion for
+// representations longer than maxSuggestedLength.
+func (meta Metadata[T]) suggest(representation string, r Representation) []string {
+	if utf8.RuneCountInString(representation) > maxSuggestedLength {
+		return nil
+	}
+
+	key := []rune(meta.Parsing.key(representation))
+	maxDistance := len(key) / 4
+	if maxDistance < 1 {
+		maxDistance = 1
+	}
+
+	type suggestion struct {
+		representation string
+		distance       int
+	}
+	var suggestions []suggestion
+
+	strings := meta.getRepresentation(r)
+	for _, v := range meta.getValues() {
+		candidate := strings[v]
+		candidateKey := meta.Parsing.key(candidate)
+		if difference := utf8.RuneCountInString(candidateKey) - len(key); difference > maxDistance || -difference > maxDistance {
+			continue
+		}
+
+		distance := editDistance(key, []rune(candidateKey))
+		if distance <= maxDistance {
+			suggestions = append(suggestions, suggestion{candidate, distance})
+		}
+	}
+
+	// Stable sort to keep the order of the values between equal distances.
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].distance < suggestions[j].distance
+	})
+
+	if len(suggestions) > maxSuggestions {
+		suggestions = suggestions[:maxSuggestions]
+	}
+
+	var result []string
+	for _, s := range suggestions {
+		result = append(result, s.representation)
+	}
+
+	return result
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a []rune, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+// formatList returns the quoted strings separated by commas.
+func formatList(values []string) string {
+	s := ""
+	for i, v := range values {
+		if i > 0 {
+			s += ", "
+		}
+		s += fmt.Sprintf("%q", v)
+	}
+
+	return s
+}
@@ -0,0 +1,163 @@
+package goconstants_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/samonzeweb/goconstants"
+)
+
+func TestSuggestions(t *testing.T) {
+	testCases := []struct {
+		name                string
+		input               string
+		listAccepted        int
+		expectedSuggestions []string
+		expectedAccepted    []string
+		expectedString      string
+	}{
+		{
+			name:                "one typo",
+			input:               "hommer_simpson",
+			expectedSuggestions: []string{"homer_simpson"},
+			expectedString:      `unknown cst value: "hommer_simpson", did you mean "homer_simpson"?`,
+		},
+		{
+			name:                "several candidates",
+			input:               "marge_simpsons",
+			expectedSuggestions: []string{"marge_simpson", "maggie_simpson"},
+			expectedString:      `unknown cst value: "marge_simpsons", did you mean "marge_simpson", "maggie_simpson"?`,
+		},
+		{
+			name:           "nothing close",
+			input:          "ned_flanders",
+			expectedString: `unknown cst value: "ned_flanders"`,
+		},
+		{
+			name:         "accepted values",
+			input:        "ned_flanders",
+			listAccepted: 5,
+			expectedAccepted: []string{
+				"homer_simpson",
+				"marge_simpson",
+				"bart_simpson",
+				"lisa_simpson",
+				"maggie_simpson",
+			},
+			expectedString: `unknown cst value: "ned_flanders", accepted values are "homer_simpson", "marge_simpson", "bart_simpson", "lisa_simpson", "maggie_simpson"`,
+		},
+		{
+			name:           "too many values to be listed",
+			input:          "ned_flanders",
+			listAccepted:   4,
+			expectedString: `unknown cst value: "ned_flanders"`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			meta := cstMeta
+			meta.Strings = nil
+			meta.ListAccepted = testCase.listAccepted
+
+			_, err := meta.ParseHelper(testCase.input)
+
+			var unknownValueError *goconstants.UnknownValueError
+			if !errors.As(err, &unknownValueError) {
+				t.Fatalf("expected an UnknownValueError, got %v", err)
+			}
+
+			if !reflect.DeepEqual(unknownValueError.Suggestions, testCase.expectedSuggestions) {
+				t.Errorf("expected suggestions %v, got %v", testCase.expectedSuggestions, unknownValueError.Suggestions)
+			}
+
+			if !reflect.DeepEqual(unknownValueError.Accepted, testCase.expectedAccepted) {
+				t.Errorf("expected accepted values %v, got %v", testCase.expectedAccepted, unknownValueError.Accepted)
+			}
+
+			if err.Error() != testCase.expectedString {
+				t.Errorf("expected error %s, got %s", testCase.expectedString, err.Error())
+			}
+		})
+	}
+}
+
+func TestParseHelper(t *testing.T) {
+	value, err := cstMeta.ParseHelper("Lisa Simpson")
+	if err != nil || value != lisa {
+		t.Errorf("expected %v, got %v (%v)", lisa, value, err)
+	}
+
+	_, err = cstMeta.ParseHelper("Lisa Simson")
+	var unknownValueError *goconstants.UnknownValueError
+	if !errors.As(err, &unknownValueError) {
+		t.Fatalf("expected an UnknownValueError, got %v", err)
+	}
+
+	expected := []string{"Lisa Simpson"}
+	if !reflect.DeepEqual(unknownValueError.Suggestions, expected) {
+		t.Errorf("expected suggestions %v, got %v", expected, unknownValueError.Suggestions)
+	}
+}
+
+func TestDecodingSuggestions(t *testing.T) {
+	testCases := []struct {
+		name     string
+		parse    func(representation string) error
+		input    string
+		expected []string
+	}{
+		{
+			name: "decoding JSON",
+			parse: func(representation string) error {
+				var value simpson
+				return cstMeta.UnmarshalJSONHelper([]byte(`"`+representation+`"`), &value)
+			},
+			input:    "hommer_simpson",
+			expected: []string{"homer_simpson"},
+		},
+		{
+			name: "decoding text",
+			parse: func(representation string) error {
+				var value simpson
+				return cstMeta.UnmarshalTextHelper([]byte(representation), &value)
+			},
+			input:    "lisa_simpsn",
+			expected: []string{"lisa_simpson"},
+		},
+		{
+			name: "scanning",
+			parse: func(representation string) error {
+				meta := cstMeta
+				meta.DBStrings = map[simpson]string{homer: "homer", bart: "bart"}
+				var value simpson
+				return meta.ScanHelper(representation, &value)
+			},
+			input:    "barts",
+			expected: []string{"bart"},
+		},
+		{
+			name: "long representation",
+			parse: func(representation string) error {
+				_, err := cstMeta.ParseHelper(representation)
+				return err
+			},
+			input: "Homer Simpson" + strings.Repeat(" ", 60),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var unknownValueError *goconstants.UnknownValueError
+			if err := testCase.parse(testCase.input); !errors.As(err, &unknownValueError) {
+				t.Fatalf("expected an UnknownValueError, got %v", err)
+			}
+
+			if !reflect.DeepEqual(unknownValueError.Suggestions, testCase.expected) {
+				t.Errorf("expected suggestions %v, got %v", testCase.expected, unknownValueError.Suggestions)
+			}
+		})
+	}
+}
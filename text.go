@@ -0,0 +1,47 @@
+package goconstants
+
+import "fmt"
+
+// MarshalTextHelper allows the implementation of encoding.TextMarshaler for
+// the associated constant type. The representation is chosen with the Text
+// field.
+// Implementing encoding.TextMarshaler allows the use of the constant type
+// as JSON map keys, XML attributes, ...
+func (meta Metadata[T]) MarshalTextHelper(v T) ([]byte, error) {
+	v, err := meta.marshalled(v)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal %s type to text: %w", meta.Name, err)
+	}
+
+	var representation string
+	if meta.Flags {
+		representation, err = meta.formatFlags(v, meta.Text)
+	} else {
+		representation, err = meta.toStringHelper(v, meta.getRepresentation(meta.Text))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal %s type to text: %w", meta.Name, err)
+	}
+
+	return []byte(representation), nil
+}
+
+// UnmarshalTextHelper allows the implementation of encoding.TextUnmarshaler
+// for the associated constant type. The representation is chosen with the
+// Text field.
+// It returns an UnknownValueError if the text is unknown, and a
+// DeprecatedValueError if the value is deprecated and rejected.
+func (meta Metadata[T]) UnmarshalTextHelper(b []byte, v *T) error {
+	decode := meta.decode
+	if meta.Flags {
+		decode = meta.decodeFlags
+	}
+
+	value, err := decode(string(b), meta.Text)
+	if err != nil {
+		return err
+	}
+
+	*v = value
+	return nil
+}
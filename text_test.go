@@ -0,0 +1,168 @@
+package goconstants_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/samonzeweb/goconstants"
+)
+
+func TestMarshalTextHelper(t *testing.T) {
+	testCases := []struct {
+		name           string
+		representation goconstants.Representation
+		input          simpson
+		expected       []byte
+	}{
+		{
+			name:           "default representation",
+			representation: goconstants.JSONRepresentation,
+			input:          homer,
+			expected:       []byte("homer_simpson"),
+		},
+		{
+			name:           "string representation",
+			representation: goconstants.StringRepresentation,
+			input:          homer,
+			expected:       []byte("Homer Simpson"),
+		},
+		{
+			name:           "invalid value",
+			representation: goconstants.JSONRepresentation,
+			input:          999,
+			expected:       nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			meta := cstMeta
+			meta.Text = testCase.representation
+
+			b, err := meta.MarshalTextHelper(testCase.input)
+
+			if !reflect.DeepEqual(b, testCase.expected) {
+				t.Errorf("expected %s, got %s", string(testCase.expected), string(b))
+			}
+
+			if testCase.expected != nil && err != nil {
+				t.Errorf("unexpected error %v", err)
+			}
+
+			if testCase.expected == nil && !errors.Is(err, goconstants.ErrUnknownValue) {
+				t.Errorf("expected an unknown value error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestUnmarshalTextHelper(t *testing.T) {
+	testCases := []struct {
+		name           string
+		representation goconstants.Representation
+		input          []byte
+		expected       simpson
+	}{
+		{
+			name:           "default representation",
+			representation: goconstants.JSONRepresentation,
+			input:          []byte("lisa_simpson"),
+			expected:       lisa,
+		},
+		{
+			name:           "string representation",
+			representation: goconstants.StringRepresentation,
+			input:          []byte("Lisa Simpson"),
+			expected:       lisa,
+		},
+		{
+			name:           "invalid value",
+			representation: goconstants.JSONRepresentation,
+			input:          []byte("Lisa Simpson"),
+			expected:       0,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			meta := cstMeta
+			meta.Text = testCase.representation
+
+			var value simpson
+			err := meta.UnmarshalTextHelper(testCase.input, &value)
+
+			if value != testCase.expected {
+				t.Errorf("expected %v, got %v", testCase.expected, value)
+			}
+
+			if testCase.expected != 0 && err != nil {
+				t.Errorf("unexpected error %v", err)
+			}
+
+			if testCase.expected == 0 && !errors.Is(err, goconstants.ErrUnknownValue) {
+				t.Errorf("expected an unknown value error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestTextMapKeys(t *testing.T) {
+	counts := map[GopherState]int{
+		GopherAsleep: 3,
+		GopherCoding: 42,
+	}
+
+	b, err := json.Marshal(counts)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	expected := `{"Coding":42,"Zzz":3}`
+	if string(b) != expected {
+		t.Errorf("expected %s, got %s", expected, string(b))
+	}
+
+	var decoded map[GopherState]int
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if !reflect.DeepEqual(decoded, counts) {
+		t.Errorf("expected %v, got %v", counts, decoded)
+	}
+
+	err = json.Unmarshal([]byte(`{"Walking on the moon":1}`), &decoded)
+	if !errors.Is(err, goconstants.ErrUnknownValue) {
+		t.Errorf("expected an unknown value error, got %v", err)
+	}
+}
+
+func TestValidateText(t *testing.T) {
+	meta := cstMeta
+	meta.Text = goconstants.Representation(-1)
+
+	if err := meta.Validate(); !errors.Is(err, goconstants.ErrUnknownRepresentation) {
+		t.Errorf("validate didn't catch unknown text representation")
+	}
+
+	testCases := []struct {
+		name string
+		text goconstants.Representation
+	}{
+		{name: "db", text: goconstants.DBRepresentation},
+		{name: "proto", text: goconstants.ProtoRepresentation},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			meta := cstMeta
+			meta.Text = testCase.text
+
+			if err := meta.Validate(); !errors.Is(err, goconstants.ErrTextUndefined) {
+				t.Errorf("expected error %v, got %v", goconstants.ErrTextUndefined, err)
+			}
+		})
+	}
+}